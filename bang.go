@@ -0,0 +1,74 @@
+package hunt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Bang is a "!name" token in a query that selects an engine by name
+type Bang struct {
+	Token  string // The token as typed, including the "!"
+	Engine SelectedEngine
+}
+
+// NormalizeEngineName reduces an engine name to lowercase letters and digits,
+// so "Hacker News", "hacker-news" and "HackerNews" all compare equal
+func NormalizeEngineName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// FindBangEngine returns the engine a bang name refers to, searching every category
+// in CategoryNames order. Names are compared with NormalizeEngineName.
+func (c *Config) FindBangEngine(name string) (SelectedEngine, bool) {
+	want := NormalizeEngineName(name)
+	if want == "" {
+		return SelectedEngine{}, false
+	}
+	for _, category := range c.CategoryNames() {
+		for _, engine := range c.Categories[category] {
+			if NormalizeEngineName(engine.Name) == want {
+				return SelectedEngine{Category: category, Engine: engine}, true
+			}
+		}
+	}
+	return SelectedEngine{}, false
+}
+
+// ParseBangs extracts bangs from anywhere in query and returns them with the remaining query
+// A token is a bang if it starts with "!" and names an engine in config. Other tokens stay
+// in the query, including "!" tokens that name no engine, which are also returned as unknown.
+// Each engine is selected at most once.
+func ParseBangs(config *Config, query string) (bangs []Bang, rest string, unknown []string) {
+	var kept []string
+	seen := make(map[string]bool)
+	for _, token := range strings.Fields(query) {
+		if !strings.HasPrefix(token, "!") {
+			kept = append(kept, token)
+			continue
+		}
+		selected, ok := config.FindBangEngine(token[1:])
+		if !ok {
+			kept = append(kept, token)
+			if NormalizeEngineName(token) != "" {
+				unknown = append(unknown, token)
+			}
+			continue
+		}
+		key := selected.Category + "\x00" + selected.Engine.Name
+		if !seen[key] {
+			seen[key] = true
+			bangs = append(bangs, Bang{Token: token, Engine: selected})
+		}
+	}
+	if len(bangs) == 0 {
+		// Leave the query exactly as typed
+		return nil, query, unknown
+	}
+	return bangs, strings.Join(kept, " "), unknown
+}
@@ -0,0 +1,70 @@
+package hunt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeEngineName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Hacker News", "hackernews"},
+		{"hacker-news", "hackernews"},
+		{"The Verge", "theverge"},
+		{"9to5Mac", "9to5mac"},
+		{"Ars Technica!", "arstechnica"},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeEngineName(tt.name); got != tt.want {
+			t.Errorf("NormalizeEngineName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseBangs(t *testing.T) {
+	config := &Config{Categories: map[string][]SearchEngine{
+		"search":   {{Name: "Bing"}, {Name: "YouTube"}},
+		"shop":     {{Name: "Amazon"}},
+		"technews": {{Name: "Hacker News"}, {Name: "YouTube"}},
+	}}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantEngines []string // category/name
+		wantRest    string
+		wantUnknown []string
+	}{
+		{"no bangs", "rust  async", nil, "rust  async", nil},
+		{"leading bang", "!youtube cats", []string{"search/YouTube"}, "cats", nil},
+		{"bangs anywhere", "rust !hackernews async !AMAZON", []string{"technews/Hacker News", "shop/Amazon"}, "rust async", nil},
+		{"punctuation ignored", "!hacker-news rust", []string{"technews/Hacker News"}, "rust", nil},
+		{"duplicate bang", "!bing !Bing cats", []string{"search/Bing"}, "cats", nil},
+		{"unknown bang kept", "!nope !bing cats", []string{"search/Bing"}, "!nope cats", []string{"!nope"}},
+		{"only unknown bangs", "!nope cats", nil, "!nope cats", []string{"!nope"}},
+		{"bare exclamation", "! cats", nil, "! cats", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bangs, rest, unknown := ParseBangs(config, tt.query)
+			var engines []string
+			for _, bang := range bangs {
+				engines = append(engines, bang.Engine.Category+"/"+bang.Engine.Engine.Name)
+			}
+			if strings.Join(engines, ",") != strings.Join(tt.wantEngines, ",") {
+				t.Errorf("ParseBangs(%q) engines = %v, want %v", tt.query, engines, tt.wantEngines)
+			}
+			if rest != tt.wantRest {
+				t.Errorf("ParseBangs(%q) rest = %q, want %q", tt.query, rest, tt.wantRest)
+			}
+			if strings.Join(unknown, ",") != strings.Join(tt.wantUnknown, ",") {
+				t.Errorf("ParseBangs(%q) unknown = %v, want %v", tt.query, unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
@@ -1,59 +1,483 @@
-package main
+package hunt
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
-// OpenURL opens a URL in the default browser (cross-platform)
-func OpenURL(url string) error {
-	var cmd *exec.Cmd
+// OpenTarget is a single URL to open, along with the engine it belongs to
+type OpenTarget struct {
+	Name    string
+	URL     string
+	Private bool // Open in a private/incognito window
+}
+
+// PrivateBrowser describes a browser that can open URLs in a private window
+type PrivateBrowser struct {
+	Name        string // Display name (e.g., "Firefox")
+	Executable  string // Executable on PATH, or application name on macOS
+	PrivateFlag string // Flag that opens a private window
+}
+
+// privateBrowserCandidates lists browsers with private-window support, in order of preference
+var privateBrowserCandidates = []PrivateBrowser{
+	{Name: "Firefox", Executable: "firefox", PrivateFlag: "--private-window"},
+	{Name: "Chromium", Executable: "chromium", PrivateFlag: "--incognito"},
+	{Name: "Chromium", Executable: "chromium-browser", PrivateFlag: "--incognito"},
+	{Name: "Google Chrome", Executable: "google-chrome", PrivateFlag: "--incognito"},
+	{Name: "Google Chrome", Executable: "google-chrome-stable", PrivateFlag: "--incognito"},
+	{Name: "Google Chrome", Executable: "chrome", PrivateFlag: "--incognito"},
+	{Name: "Brave", Executable: "brave-browser", PrivateFlag: "--incognito"},
+	{Name: "Brave", Executable: "brave", PrivateFlag: "--incognito"},
+	{Name: "Vivaldi", Executable: "vivaldi", PrivateFlag: "--incognito"},
+}
+
+// macAppNames maps browser display names to their macOS application bundle names
+var macAppNames = map[string]string{
+	"Firefox":       "Firefox",
+	"Chromium":      "Chromium",
+	"Google Chrome": "Google Chrome",
+	"Brave":         "Brave Browser",
+	"Vivaldi":       "Vivaldi",
+}
+
+// FindPrivateBrowser locates an installed browser that supports private windows
+// Returns an error listing the browsers that were tried if none is found
+func FindPrivateBrowser() (*PrivateBrowser, error) {
+	tried := make([]string, 0, len(privateBrowserCandidates))
+	seen := make(map[string]bool)
+
+	for _, candidate := range privateBrowserCandidates {
+		if !seen[candidate.Name] {
+			tried = append(tried, candidate.Name)
+			seen[candidate.Name] = true
+		}
+
+		if runtime.GOOS == "darwin" {
+			if app, ok := findMacApp(candidate.Name); ok {
+				browser := candidate
+				browser.Executable = app
+				return &browser, nil
+			}
+			continue
+		}
+
+		if _, err := exec.LookPath(candidate.Executable); err == nil {
+			browser := candidate
+			return &browser, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no browser with private window support found (tried %s)", strings.Join(tried, ", "))
+}
+
+// findMacApp checks the standard macOS application folders for a browser's app bundle
+func findMacApp(name string) (string, bool) {
+	app, ok := macAppNames[name]
+	if !ok {
+		return "", false
+	}
+
+	dirs := []string{"/Applications"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Applications"))
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, app+".app")); err == nil {
+			return app, true
+		}
+	}
+	return "", false
+}
+
+// command builds the command that opens url in a private window of this browser
+func (b *PrivateBrowser) command(url string) *exec.Cmd {
+	if runtime.GOOS == "darwin" {
+		// -n forces a new instance so the flag is honored when the browser is already running
+		return exec.Command("open", "-na", b.Executable, "--args", b.PrivateFlag, url)
+	}
+	return exec.Command(b.Executable, b.PrivateFlag, url)
+}
+
+// OpenStrategy controls how multiple URLs are handed to the browser
+type OpenStrategy string
+
+const (
+	// StrategySequential opens one URL at a time, waiting for each opener to exit and pausing between them
+	StrategySequential OpenStrategy = "sequential"
+	// StrategyStaggered launches openers in parallel (up to Concurrency at once), staggering each start by Delay
+	StrategyStaggered OpenStrategy = "staggered-parallel"
+	// StrategyAdaptive waits for each opener to exit, or for Timeout, then pauses for Delay only if
+	// that launch failed or took longer than Delay; quick launches go straight on to the next
+	StrategyAdaptive OpenStrategy = "adaptive"
+)
+
+// OpenStrategies lists the valid strategy names
+var OpenStrategies = []OpenStrategy{StrategySequential, StrategyStaggered, StrategyAdaptive}
+
+// ParseOpenStrategy validates a strategy name
+func ParseOpenStrategy(name string) (OpenStrategy, error) {
+	for _, strategy := range OpenStrategies {
+		if strings.EqualFold(name, string(strategy)) {
+			return strategy, nil
+		}
+	}
+	return "", fmt.Errorf("unknown open strategy %q (valid: sequential, staggered-parallel, adaptive)", name)
+}
+
+// OpenOptions controls how an Opener launches the browser
+type OpenOptions struct {
+	Strategy    OpenStrategy
+	Delay       time.Duration // Pause between launches
+	Concurrency int           // Maximum simultaneous openers (staggered-parallel only)
+	Timeout     time.Duration // Maximum wait for each opener to exit before detaching from it
+	Verbose     bool          // Report how long each launch took
+	TestMode    bool          // Skip all delays
+}
+
+// DefaultOpenOptions returns the options used when neither config nor flags override them
+func DefaultOpenOptions() OpenOptions {
+	return OpenOptions{
+		Strategy:    StrategySequential,
+		Delay:       300 * time.Millisecond,
+		Concurrency: 4,
+		Timeout:     3 * time.Second,
+	}
+}
+
+// openerCommand builds the command that opens a target, using the private browser if required
+func openerCommand(target OpenTarget, browser *PrivateBrowser) (*exec.Cmd, error) {
+	if target.Private {
+		if browser == nil {
+			return nil, fmt.Errorf("no private browser available")
+		}
+		return browser.command(target.URL), nil
+	}
 
 	switch runtime.GOOS {
 	case "darwin":
 		// macOS
-		cmd = exec.Command("open", url)
+		return exec.Command("open", target.URL), nil
 	case "linux":
 		// Linux
-		cmd = exec.Command("xdg-open", url)
+		return exec.Command("xdg-open", target.URL), nil
 	case "windows":
 		// Windows
-		cmd = exec.Command("cmd", "/c", "start", url)
+		return exec.Command("cmd", "/c", "start", target.URL), nil
 	default:
-		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+		return nil, fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 	}
+}
+
+// stderrLimit caps how much opener error output is kept for error messages
+const stderrLimit = 1024
 
-	// Suppress error output (similar to bash version's 2>/dev/null)
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// limitedBuffer keeps the first stderrLimit bytes written to it and discards the rest
+type limitedBuffer struct {
+	buf bytes.Buffer
 }
 
-// OpenURLs opens multiple URLs sequentially with delays
-// If testMode is true, delays are skipped
-func OpenURLs(urls []string, engineNames []string, testMode bool) error {
-	for i, url := range urls {
-		if i < len(engineNames) {
-			fmt.Printf("Opening %s...\n", engineNames[i])
-		} else {
-			fmt.Printf("Opening URL %d...\n", i+1)
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := stderrLimit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// launch starts the opener for target and waits up to wait for it to exit
+// A zero wait returns as soon as the opener has started. If the opener is still running
+// when the wait expires or ctx is cancelled, launch detaches from it and reports detached;
+// the process keeps running in its own process group and is reaped in the background.
+func launch(ctx context.Context, target OpenTarget, browser *PrivateBrowser, wait time.Duration) (detached bool, err error) {
+	cmd, err := openerCommand(target, browser)
+	if err != nil {
+		return false, err
+	}
+
+	// Keep opener error output for the failure summary instead of interleaving it with ours
+	stderr := &limitedBuffer{}
+	cmd.Stderr = stderr
+	// Openers such as xdg-open may hand their stderr to a long-lived browser; stop waiting
+	// for it shortly after the opener itself exits
+	cmd.WaitDelay = 250 * time.Millisecond
+	detachProcess(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	if wait <= 0 {
+		return true, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err == nil || errors.Is(err, exec.ErrWaitDelay) {
+			return false, nil
 		}
+		if output := strings.TrimSpace(stderr.buf.String()); output != "" {
+			return false, fmt.Errorf("%w: %s", err, output)
+		}
+		return false, err
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, nil
+	}
+}
 
-		if err := OpenURL(url); err != nil {
-			// Continue on error (similar to bash version)
-			fmt.Fprintf(os.Stderr, "Warning: Failed to open URL: %v\n", err)
+// OpenURL opens a URL in the default browser (cross-platform)
+// It waits up to the default timeout for the opener to exit
+func OpenURL(url string) error {
+	_, err := launch(context.Background(), OpenTarget{URL: url}, nil, DefaultOpenOptions().Timeout)
+	return err
+}
+
+// OpenPrivateURL opens a URL in a private window of the given browser
+// The browser process is started but not waited on, since a freshly launched
+// browser keeps running in the foreground until it is closed
+func OpenPrivateURL(url string, browser *PrivateBrowser) error {
+	_, err := launch(context.Background(), OpenTarget{URL: url, Private: true}, browser, 0)
+	return err
+}
+
+// OpenFailure records a target that could not be opened
+type OpenFailure struct {
+	Name string
+	Err  error
+}
+
+// OpenError is returned by Opener.Open when one or more targets failed to open or were skipped
+type OpenError struct {
+	Opened   int           // Number of targets that opened successfully
+	Failures []OpenFailure // Failed targets, in the order they were given
+	Skipped  []string      // Targets never launched because the context was cancelled
+	Cause    error         // Why targets were skipped (the context's error), if any
+}
+
+func (e *OpenError) Error() string {
+	var parts []string
+	if len(e.Failures) > 0 {
+		details := make([]string, len(e.Failures))
+		for i, failure := range e.Failures {
+			details[i] = fmt.Sprintf("%s (%v)", failure.Name, failure.Err)
+		}
+		parts = append(parts, fmt.Sprintf("failed to open %d of %d services: %s",
+			len(e.Failures), e.Opened+len(e.Failures)+len(e.Skipped), strings.Join(details, ", ")))
+	}
+	if len(e.Skipped) > 0 {
+		parts = append(parts, fmt.Sprintf("skipped %d services (%v): %s",
+			len(e.Skipped), e.Cause, strings.Join(e.Skipped, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Unwrap returns the underlying error for each failure, plus the cancellation cause
+func (e *OpenError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures)+1)
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
+// Total reports whether nothing was opened
+func (e *OpenError) Total() bool {
+	return e.Opened == 0
+}
+
+// Interrupted reports whether some targets were skipped because the context was cancelled
+func (e *OpenError) Interrupted() bool {
+	return len(e.Skipped) > 0
+}
+
+// errSkipped marks targets that were never launched because the context was cancelled
+var errSkipped = errors.New("skipped")
+
+// Opener opens targets in the browser, reporting progress as it goes
+// The zero value opens targets sequentially with no delay or timeout and reports nothing;
+// use DefaultOpenOptions for the command's defaults.
+type Opener struct {
+	Options OpenOptions
+	Browser *PrivateBrowser // Opens targets marked as private; required if any are
+	Out     io.Writer       // Progress ("Opening Bing..."); nil discards it
+	Err     io.Writer       // Per-target warnings; nil discards them
+
+	mu sync.Mutex // Serializes writes to Out and Err from parallel launches
+}
+
+// Open opens every target using the configured strategy
+// Every target is attempted until ctx is cancelled; remaining targets are then skipped.
+// If any target fails or is skipped, the returned error is an *OpenError.
+func (o *Opener) Open(ctx context.Context, targets []OpenTarget) error {
+	errs := make([]error, len(targets))
+	opts := o.Options
+
+	switch opts.Strategy {
+	case StrategyStaggered:
+		o.openStaggered(ctx, targets, errs)
+	case StrategyAdaptive:
+		for i, target := range targets {
+			started := time.Now()
+			errs[i] = o.openTarget(ctx, i, target, sequentialWait(target, opts))
+
+			// A quick, successful launch means the browser is keeping up; back off only when it isn't
+			if i < len(targets)-1 && (errs[i] != nil || time.Since(started) >= opts.Delay) {
+				pause(ctx, opts)
+			}
 		}
+	default:
+		for i, target := range targets {
+			errs[i] = o.openTarget(ctx, i, target, sequentialWait(target, opts))
 
-		// Small delay to ensure browser processes each URL as a separate tab
-		// Skip delay in test mode
-		if !testMode && i < len(urls)-1 {
-			time.Sleep(300 * time.Millisecond)
+			// Small delay to ensure browser processes each URL as a separate tab
+			if i < len(targets)-1 {
+				pause(ctx, opts)
+			}
 		}
 	}
 
+	result := &OpenError{}
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			result.Opened++
+		case errors.Is(err, errSkipped):
+			result.Skipped = append(result.Skipped, targetName(i, targets[i]))
+		default:
+			result.Failures = append(result.Failures, OpenFailure{Name: targetName(i, targets[i]), Err: err})
+		}
+	}
+	if len(result.Skipped) > 0 {
+		result.Cause = ctx.Err()
+	}
+	if len(result.Failures) > 0 || len(result.Skipped) > 0 {
+		return result
+	}
 	return nil
 }
 
+// openStaggered launches openers in parallel, starting each one Delay after the previous
+// The error for each target is stored at the same index in errs
+func (o *Opener) openStaggered(ctx context.Context, targets []OpenTarget, errs []error) {
+	opts := o.Options
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		if i > 0 {
+			pause(ctx, opts)
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = errSkipped
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, target OpenTarget) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = o.openTarget(ctx, i, target, sequentialWait(target, opts))
+		}(i, target)
+	}
+	wg.Wait()
+}
+
+// sequentialWait is how long the sequential strategies wait for a target's opener
+// Private windows are never waited on, since the browser itself may stay in the foreground
+func sequentialWait(target OpenTarget, opts OpenOptions) time.Duration {
+	if target.Private {
+		return 0
+	}
+	return opts.Timeout
+}
+
+// pause sleeps for the configured delay unless running in test mode or ctx is cancelled
+func pause(ctx context.Context, opts OpenOptions) {
+	if opts.TestMode || opts.Delay <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(opts.Delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// targetName returns the display name for a target, falling back to its position
+func targetName(i int, target OpenTarget) string {
+	if target.Name == "" {
+		return fmt.Sprintf("URL %d", i+1)
+	}
+	return target.Name
+}
+
+// printf writes a progress message to w unless w is nil
+func (o *Opener) printf(w io.Writer, format string, args ...any) {
+	if w == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	fmt.Fprintf(w, format, args...)
+}
+
+// openTarget launches a single target and reports progress
+// Returns errSkipped without launching anything if ctx has already been cancelled
+func (o *Opener) openTarget(ctx context.Context, i int, target OpenTarget, wait time.Duration) error {
+	if ctx.Err() != nil {
+		return errSkipped
+	}
+
+	name := targetName(i, target)
+	if target.Private && o.Browser != nil {
+		o.printf(o.Out, "Opening %s (private window, %s)...\n", name, o.Browser.Name)
+	} else {
+		o.printf(o.Out, "Opening %s...\n", name)
+	}
+
+	started := time.Now()
+	detached, err := launch(ctx, target, o.Browser, wait)
+	if err != nil {
+		// Continue on error (similar to bash version); failures are reported in the summary
+		o.printf(o.Err, "Warning: Failed to open %s: %v\n", name, err)
+		return err
+	}
 
+	if o.Options.Verbose {
+		elapsed := time.Since(started).Round(time.Millisecond)
+		if detached && wait > 0 {
+			o.printf(o.Out, "  %s launched in %s (opener still running, detached)\n", name, elapsed)
+		} else {
+			o.printf(o.Out, "  %s launched in %s\n", name, elapsed)
+		}
+	}
+	return nil
+}
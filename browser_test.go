@@ -0,0 +1,377 @@
+package hunt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFakeExecutable creates an executable shell script named name in dir
+func writeFakeExecutable(t *testing.T, dir, name string) {
+	t.Helper()
+	script := "#!/bin/sh\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake executable %s: %v", name, err)
+	}
+}
+
+func TestFindPrivateBrowser(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("private browser lookup uses PATH only on Linux and other Unix systems")
+	}
+
+	tests := []struct {
+		name        string
+		executables []string
+		wantName    string
+		wantFlag    string
+		wantErr     bool
+	}{
+		{
+			name:        "firefox",
+			executables: []string{"firefox"},
+			wantName:    "Firefox",
+			wantFlag:    "--private-window",
+		},
+		{
+			name:        "chromium",
+			executables: []string{"chromium"},
+			wantName:    "Chromium",
+			wantFlag:    "--incognito",
+		},
+		{
+			name:        "firefox preferred over chrome",
+			executables: []string{"google-chrome", "firefox"},
+			wantName:    "Firefox",
+			wantFlag:    "--private-window",
+		},
+		{
+			name:        "no browser installed",
+			executables: []string{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, exe := range tt.executables {
+				writeFakeExecutable(t, dir, exe)
+			}
+			t.Setenv("PATH", dir)
+
+			browser, err := FindPrivateBrowser()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FindPrivateBrowser() = %+v, want error", browser)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindPrivateBrowser() error = %v", err)
+			}
+			if browser.Name != tt.wantName {
+				t.Errorf("FindPrivateBrowser() name = %q, want %q", browser.Name, tt.wantName)
+			}
+			if browser.PrivateFlag != tt.wantFlag {
+				t.Errorf("FindPrivateBrowser() flag = %q, want %q", browser.PrivateFlag, tt.wantFlag)
+			}
+		})
+	}
+}
+
+func TestParseOpenStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    OpenStrategy
+		wantErr bool
+	}{
+		{name: "sequential", input: "sequential", want: StrategySequential},
+		{name: "staggered parallel", input: "staggered-parallel", want: StrategyStaggered},
+		{name: "adaptive mixed case", input: "Adaptive", want: StrategyAdaptive},
+		{name: "unknown", input: "random", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOpenStrategy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOpenStrategy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOpenStrategy(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenSettingsApply(t *testing.T) {
+	defaults := DefaultOpenOptions()
+
+	tests := []struct {
+		name     string
+		settings OpenSettings
+		want     OpenOptions
+		wantErr  bool
+	}{
+		{
+			name:     "empty settings keep defaults",
+			settings: OpenSettings{},
+			want:     defaults,
+		},
+		{
+			name:     "all fields set",
+			settings: OpenSettings{Strategy: "adaptive", Delay: "50ms", Concurrency: 2, Timeout: "1s"},
+			want:     OpenOptions{Strategy: StrategyAdaptive, Delay: 50 * time.Millisecond, Concurrency: 2, Timeout: time.Second},
+		},
+		{
+			name:     "zero delay",
+			settings: OpenSettings{Delay: "0"},
+			want:     OpenOptions{Strategy: defaults.Strategy, Delay: 0, Concurrency: defaults.Concurrency, Timeout: defaults.Timeout},
+		},
+		{name: "invalid strategy", settings: OpenSettings{Strategy: "fast"}, wantErr: true},
+		{name: "invalid delay", settings: OpenSettings{Delay: "soon"}, wantErr: true},
+		{name: "negative delay", settings: OpenSettings{Delay: "-1s"}, wantErr: true},
+		{name: "negative concurrency", settings: OpenSettings{Concurrency: -1}, wantErr: true},
+		{name: "zero timeout", settings: OpenSettings{Timeout: "0s"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.settings.Apply(defaults)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Apply() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpener_Strategies(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake opener relies on xdg-open, which is only used on Linux")
+	}
+
+	for _, strategy := range OpenStrategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			// Fake xdg-open that records each URL it was asked to open
+			dir := t.TempDir()
+			logPath := filepath.Join(dir, "opened.log")
+			script := "#!/bin/sh\necho \"$1\" >> " + logPath + "\n"
+			if err := os.WriteFile(filepath.Join(dir, "xdg-open"), []byte(script), 0755); err != nil {
+				t.Fatalf("Failed to create fake xdg-open: %v", err)
+			}
+			t.Setenv("PATH", dir)
+
+			targets := []OpenTarget{
+				{Name: "One", URL: "https://one.example/?q=test"},
+				{Name: "Two", URL: "https://two.example/?q=test"},
+				{Name: "Three", URL: "https://three.example/?q=test"},
+			}
+			opts := DefaultOpenOptions()
+			opts.Strategy = strategy
+			opts.TestMode = true
+
+			var progress strings.Builder
+			if err := (&Opener{Options: opts, Out: &progress}).Open(context.Background(), targets); err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			for _, target := range targets {
+				if want := "Opening " + target.Name + "..."; !strings.Contains(progress.String(), want) {
+					t.Errorf("Open() progress missing %q, got %q", want, progress.String())
+				}
+			}
+
+			data, err := os.ReadFile(logPath)
+			if err != nil {
+				t.Fatalf("Failed to read opener log: %v", err)
+			}
+			opened := strings.Fields(string(data))
+			if len(opened) != len(targets) {
+				t.Fatalf("Open() opened %d URLs, want %d: %v", len(opened), len(targets), opened)
+			}
+			for _, target := range targets {
+				if !slices.Contains(opened, target.URL) {
+					t.Errorf("Open() did not open %q", target.URL)
+				}
+			}
+		})
+	}
+}
+
+// fakeOpener installs a fake xdg-open on PATH that runs script as its body
+func fakeOpener(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("fake opener relies on xdg-open, which is only used on Linux")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "xdg-open"), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to create fake xdg-open: %v", err)
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestOpener_TimeoutDetachesHangingOpener(t *testing.T) {
+	sleepPath, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep command not available")
+	}
+	fakeOpener(t, "exec "+sleepPath+" 30")
+
+	targets := []OpenTarget{
+		{Name: "One", URL: "https://one.example/?q=test"},
+		{Name: "Two", URL: "https://two.example/?q=test"},
+	}
+	opts := DefaultOpenOptions()
+	opts.Timeout = 100 * time.Millisecond
+	opts.TestMode = true
+
+	started := time.Now()
+	if err := (&Opener{Options: opts}).Open(context.Background(), targets); err != nil {
+		t.Fatalf("Open() error = %v, want nil for detached openers", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Open() took %s, want it bounded by the per-launch timeout", elapsed)
+	}
+}
+
+func TestOpener_DoesNotWaitOnPrivateWindows(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake browser is run directly, which is only done on Linux")
+	}
+	sleepPath, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep command not available")
+	}
+	// A browser that stays in the foreground, as one opening a new private window may
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "fake-browser"), []byte("#!/bin/sh\nexec "+sleepPath+" 30\n"), 0755); err != nil {
+		t.Fatalf("Failed to create fake browser: %v", err)
+	}
+	t.Setenv("PATH", dir)
+	browser := &PrivateBrowser{Name: "Fake", Executable: "fake-browser", PrivateFlag: "--private-window"}
+
+	targets := []OpenTarget{
+		{Name: "One", URL: "https://one.example/?q=test", Private: true},
+		{Name: "Two", URL: "https://two.example/?q=test", Private: true},
+	}
+	for _, strategy := range []OpenStrategy{StrategySequential, StrategyAdaptive} {
+		t.Run(string(strategy), func(t *testing.T) {
+			opts := DefaultOpenOptions()
+			opts.Strategy = strategy
+			opts.Timeout = 10 * time.Second
+			opts.TestMode = true
+
+			started := time.Now()
+			if err := (&Opener{Options: opts, Browser: browser}).Open(context.Background(), targets); err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			if elapsed := time.Since(started); elapsed > 5*time.Second {
+				t.Errorf("Open() took %s, want it not to wait on private windows", elapsed)
+			}
+		})
+	}
+}
+
+func TestOpener_AdaptivePausesOnlyAfterSlowOrFailedLaunches(t *testing.T) {
+	targets := []OpenTarget{
+		{Name: "One", URL: "https://one.example/?q=test"},
+		{Name: "Two", URL: "https://two.example/?q=test"},
+		{Name: "Three", URL: "https://three.example/?q=test"},
+	}
+	const delay = 400 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		script    string
+		strategy  OpenStrategy
+		wantPause bool // Whether Open should pause between launches (2 * delay in total)
+	}{
+		{"sequential pauses after quick launches", "exit 0", StrategySequential, true},
+		{"adaptive skips the pause after quick launches", "exit 0", StrategyAdaptive, false},
+		{"adaptive pauses after failed launches", "exit 3", StrategyAdaptive, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeOpener(t, tt.script)
+			opts := DefaultOpenOptions()
+			opts.Strategy = tt.strategy
+			opts.Delay = delay
+
+			started := time.Now()
+			_ = (&Opener{Options: opts}).Open(context.Background(), targets)
+			elapsed := time.Since(started)
+			if tt.wantPause && elapsed < 2*delay {
+				t.Errorf("Open() took %s, want at least %s of pauses", elapsed, 2*delay)
+			}
+			if !tt.wantPause && elapsed >= delay {
+				t.Errorf("Open() took %s, want no pauses (under %s)", elapsed, delay)
+			}
+		})
+	}
+}
+
+func TestOpener_FailureIncludesOpenerOutput(t *testing.T) {
+	fakeOpener(t, "echo 'no method available' >&2\nexit 3")
+
+	targets := []OpenTarget{{Name: "One", URL: "https://one.example/?q=test"}}
+	opts := DefaultOpenOptions()
+	opts.TestMode = true
+
+	err := (&Opener{Options: opts}).Open(context.Background(), targets)
+	var openErr *OpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("Open() error = %v, want *OpenError", err)
+	}
+	if !openErr.Total() || len(openErr.Failures) != 1 {
+		t.Fatalf("Open() = %+v, want one failure and nothing opened", openErr)
+	}
+	if !strings.Contains(openErr.Failures[0].Err.Error(), "no method available") {
+		t.Errorf("failure error = %q, want it to include opener stderr", openErr.Failures[0].Err)
+	}
+}
+
+func TestOpener_CancelledContextSkipsRemaining(t *testing.T) {
+	fakeOpener(t, "exit 0")
+
+	targets := []OpenTarget{
+		{Name: "One", URL: "https://one.example/?q=test"},
+		{Name: "Two", URL: "https://two.example/?q=test"},
+	}
+
+	for _, strategy := range OpenStrategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			opts := DefaultOpenOptions()
+			opts.Strategy = strategy
+			opts.TestMode = true
+
+			err := (&Opener{Options: opts}).Open(ctx, targets)
+			var openErr *OpenError
+			if !errors.As(err, &openErr) {
+				t.Fatalf("Open() error = %v, want *OpenError", err)
+			}
+			if !openErr.Interrupted() || len(openErr.Skipped) != len(targets) {
+				t.Errorf("Open() skipped %v, want all targets skipped", openErr.Skipped)
+			}
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Open() error = %v, want it to wrap context.Canceled", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package hunt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// chromePlaceholder marks where the query goes in the URLs Chrome shows and exports
+const chromePlaceholder = "%s"
+
+// ChromeSearchEngine is a keyword search: a site search exported from Chrome, or a
+// bookmark with a keyword from a bookmarks HTML file
+type ChromeSearchEngine struct {
+	Name    string `json:"name"`
+	Keyword string `json:"keyword"`
+	URL     string `json:"url"` // Template containing %s (or {searchTerms})
+}
+
+var (
+	// bookmarkLinkPattern matches <A ...>title</A> in the Netscape bookmarks format
+	bookmarkLinkPattern = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+	// bookmarkAttrPattern matches one NAME="value" attribute
+	bookmarkAttrPattern = regexp.MustCompile(`(?s)([A-Za-z_-]+)\s*=\s*"([^"]*)"`)
+)
+
+// ParseChromeSearchEngines reads keyword searches from a JSON list of {name, keyword, url}
+// objects or from a bookmarks HTML export, where keyword bookmarks carry SHORTCUTURL
+func ParseChromeSearchEngines(r io.Reader) ([]ChromeSearchEngine, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var entries []struct {
+			ChromeSearchEngine
+			ShortName string `json:"short_name"` // Column name in Chrome's Web Data database
+		}
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse search engine list: %w", err)
+		}
+		engines := make([]ChromeSearchEngine, len(entries))
+		for i, entry := range entries {
+			engines[i] = entry.ChromeSearchEngine
+			if engines[i].Name == "" {
+				engines[i].Name = entry.ShortName
+			}
+		}
+		return engines, nil
+	}
+
+	if !bytes.Contains(bytes.ToUpper(trimmed[:min(len(trimmed), 512)]), []byte("NETSCAPE-BOOKMARK-FILE")) {
+		return nil, fmt.Errorf("not a search engine list (JSON) or bookmarks HTML file")
+	}
+	var engines []ChromeSearchEngine
+	for _, link := range bookmarkLinkPattern.FindAllSubmatch(data, -1) {
+		attrs := make(map[string]string)
+		for _, attr := range bookmarkAttrPattern.FindAllSubmatch(link[1], -1) {
+			attrs[strings.ToUpper(string(attr[1]))] = html.UnescapeString(string(attr[2]))
+		}
+		if attrs["SHORTCUTURL"] == "" {
+			continue // Only keyword bookmarks are searches
+		}
+		engines = append(engines, ChromeSearchEngine{
+			Name:    strings.TrimSpace(html.UnescapeString(string(link[2]))),
+			Keyword: attrs["SHORTCUTURL"],
+			URL:     attrs["HREF"],
+		})
+	}
+	return engines, nil
+}
+
+// Engine converts the keyword search into a SearchEngine, named after the keyword when untitled
+func (e ChromeSearchEngine) Engine() (SearchEngine, error) {
+	name := strings.TrimSpace(e.Name)
+	if name == "" {
+		name = strings.TrimSpace(e.Keyword)
+	}
+	if name == "" {
+		return SearchEngine{}, fmt.Errorf("no name or keyword")
+	}
+
+	template := strings.Replace(e.URL, openSearchPlaceholder, chromePlaceholder, 1)
+	prefix, ok := TemplateToPrefix(template, chromePlaceholder)
+	if !ok {
+		return SearchEngine{}, fmt.Errorf("%%s is not at the end of %s", e.URL)
+	}
+	if strings.Contains(prefix, "{") {
+		return SearchEngine{}, fmt.Errorf("unsupported template parameters in %s", e.URL)
+	}
+	return SearchEngine{Name: name, URL: prefix, Encoding: prefixEncoding(prefix)}, nil
+}
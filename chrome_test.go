@@ -0,0 +1,87 @@
+package hunt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseChromeSearchEngines(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []SearchEngine
+		wantErr []string // Engine() error substrings, in order, for entries that can't convert
+	}{
+		{
+			name: "JSON list",
+			input: `[
+				{"name": "MDN", "keyword": "mdn", "url": "https://developer.mozilla.org/search?q=%s"},
+				{"short_name": "Crates", "keyword": "crates", "url": "https://crates.io/search?q={searchTerms}"},
+				{"name": "", "keyword": "wiki", "url": "https://en.wikipedia.org/wiki/%s"},
+				{"name": "Google", "keyword": "g", "url": "{google:baseURL}search?q=%s"},
+				{"name": "Middle", "keyword": "m", "url": "https://example.com/?q=%s&x=1"}
+			]`,
+			want: []SearchEngine{
+				{Name: "MDN", URL: "https://developer.mozilla.org/search?q=", Encoding: Encoding{Delimiter: "+"}},
+				{Name: "Crates", URL: "https://crates.io/search?q=", Encoding: Encoding{Delimiter: "+"}},
+				{Name: "wiki", URL: "https://en.wikipedia.org/wiki/", Encoding: Encoding{Escape: EscapePath, Delimiter: "%20"}},
+			},
+			wantErr: []string{"unsupported template parameters", "not at the end"},
+		},
+		{
+			name: "bookmarks HTML",
+			input: `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://pkg.go.dev/search?q=%s&amp;m=package" ADD_DATE="1" SHORTCUTURL="go">Go &amp; Packages</A>
+    <DT><A HREF="https://go.dev/" ADD_DATE="1">Go home</A>
+    <DT><a href="https://news.ycombinator.com/item?id=%s" shortcuturl="hn">HN</a>
+</DL><p>`,
+			want: []SearchEngine{
+				{Name: "HN", URL: "https://news.ycombinator.com/item?id=", Encoding: Encoding{Delimiter: "+"}},
+			},
+			wantErr: []string{"not at the end"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches, err := ParseChromeSearchEngines(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseChromeSearchEngines() error = %v", err)
+			}
+			var got []SearchEngine
+			var errs []string
+			for _, search := range searches {
+				engine, err := search.Engine()
+				if err != nil {
+					errs = append(errs, err.Error())
+					continue
+				}
+				got = append(got, engine)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("engines = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
+					t.Errorf("engine %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+			if len(errs) != len(tt.wantErr) {
+				t.Fatalf("errors = %v, want %v", errs, tt.wantErr)
+			}
+			for i := range errs {
+				if !strings.Contains(errs[i], tt.wantErr[i]) {
+					t.Errorf("error %d = %q, want one containing %q", i, errs[i], tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseChromeSearchEngines_UnknownFormat(t *testing.T) {
+	if _, err := ParseChromeSearchEngines(strings.NewReader("<html><body>hi</body></html>")); err == nil {
+		t.Error("expected an error for a file that is neither JSON nor a bookmarks export")
+	}
+}
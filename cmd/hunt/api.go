@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aneely/hunt"
+)
+
+// apiVersion is included in every API response so clients can detect schema changes
+// Breaking changes get a new version and a new /api/vN/ prefix; v1 only ever gains fields.
+const apiVersion = "v1"
+
+// maxAPIRequestBytes bounds POST bodies
+const maxAPIRequestBytes = 1 << 20
+
+// APICategory describes one category in GET /api/v1/categories
+type APICategory struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	EngineCount int    `json:"engine_count"`
+}
+
+// APIEngine describes one engine in GET /api/v1/engines
+// Index is the 1-based number accepted as a selection within its category
+type APIEngine struct {
+	Category string        `json:"category"`
+	Index    int           `json:"index"`
+	Name     string        `json:"name"`
+	URL      string        `json:"url"`
+	Encoding hunt.Encoding `json:"encoding"`
+	Private  bool          `json:"private,omitempty"`
+}
+
+// APIBuildRequest is the body of POST /api/v1/build
+// Category defaults to "search"; no selections means every engine in the category
+type APIBuildRequest struct {
+	Query      string   `json:"query"`
+	Category   string   `json:"category,omitempty"`
+	Selections []string `json:"selections,omitempty"`
+}
+
+// APIBuildResult is one search URL in a build response
+// POST searches have Method "POST": URL is then the form action and Form its fields
+type APIBuildResult struct {
+	Name    string           `json:"name"`
+	URL     string           `json:"url"`
+	Query   string           `json:"query,omitempty"`   // The query after the operator dialect and query_transform, when it differs
+	Dropped []string         `json:"dropped,omitempty"` // Parts of the query the engine doesn't support
+	Method  string           `json:"method,omitempty"`
+	Form    []hunt.FormField `json:"form,omitempty"`
+	Charset string           `json:"charset,omitempty"`
+	Private bool             `json:"private,omitempty"`
+}
+
+// APIError is the structured error returned with every non-2xx response
+type APIError struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// API error codes
+const (
+	apiErrInvalidRequest   = "invalid_request"
+	apiErrUnknownCategory  = "unknown_category"
+	apiErrInvalidSelection = "invalid_selection"
+	apiErrNotFound         = "not_found"
+	apiErrMethodNotAllowed = "method_not_allowed"
+)
+
+// runAPI implements `hunt api`: a JSON API over the engine catalog and URL building
+// Returns the process exit code
+func runAPI(args []string) int {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "Address to listen on")
+	fs.Usage = func() { printAPIUsage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return flagExitCode(err)
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n", fs.Arg(0))
+		return exitUsage
+	}
+
+	return runServer("api", *addr, newAPIHandler)
+}
+
+// printAPIUsage prints help for `hunt api`
+func printAPIUsage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: %s api [--addr HOST:PORT]\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Serves hunt's engine catalog and URL building as JSON:\n")
+	fmt.Fprintf(w, "  GET  /api/v1/categories\n")
+	fmt.Fprintf(w, "  GET  /api/v1/engines[?category=NAME]\n")
+	fmt.Fprintf(w, "  POST /api/v1/build  {\"query\": \"...\", \"category\": \"search\", \"selections\": [\"1\", \"Google\"]}\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Errors are returned as {\"api_version\": \"v1\", \"error\": {\"code\": ..., \"message\": ...}}.\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Options:\n")
+	fs.PrintDefaults()
+}
+
+// newAPIHandler returns the handler for `hunt api`
+func newAPIHandler(configs *configWatcher) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/categories", func(w http.ResponseWriter, r *http.Request) {
+		handleAPICategories(w, configs.Config())
+	})
+	mux.HandleFunc("GET /api/v1/engines", func(w http.ResponseWriter, r *http.Request) {
+		handleAPIEngines(w, r, configs.Config())
+	})
+	mux.HandleFunc("POST /api/v1/build", func(w http.ResponseWriter, r *http.Request) {
+		handleAPIBuild(w, r, configs.Config())
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		// Let ServeMux choose between 404 and 405 (with its Allow header), then answer in JSON
+		unmatched := &statusRecorder{header: http.Header{}}
+		mux.ServeHTTP(unmatched, r)
+		if unmatched.status == http.StatusMethodNotAllowed {
+			allow := unmatched.header.Get("Allow")
+			w.Header().Set("Allow", allow)
+			writeAPIError(w, http.StatusMethodNotAllowed, APIError{
+				Code:    apiErrMethodNotAllowed,
+				Message: fmt.Sprintf("%s %s is not supported (allowed: %s)", r.Method, r.URL.Path, allow),
+			})
+			return
+		}
+		writeAPIError(w, http.StatusNotFound, APIError{
+			Code:    apiErrNotFound,
+			Message: fmt.Sprintf("no endpoint for %s %s", r.Method, r.URL.Path),
+		})
+	})
+}
+
+// statusRecorder keeps the status and headers a handler writes and discards its body
+type statusRecorder struct {
+	header http.Header
+	status int
+}
+
+func (r *statusRecorder) Header() http.Header { return r.header }
+
+func (r *statusRecorder) Write(p []byte) (int, error) { return len(p), nil }
+
+func (r *statusRecorder) WriteHeader(status int) { r.status = status }
+
+// handleAPICategories lists every category, "search" first and the rest alphabetically
+func handleAPICategories(w http.ResponseWriter, config *hunt.Config) {
+	categories := []APICategory{}
+	for _, name := range config.CategoryNames() {
+		categories = append(categories, APICategory{
+			Name:        name,
+			Title:       hunt.FormatCategoryName(name),
+			EngineCount: len(config.Categories[name]),
+		})
+	}
+	writeAPIResponse(w, http.StatusOK, map[string]any{"categories": categories})
+}
+
+// handleAPIEngines lists the engines in the requested category, or in every category
+func handleAPIEngines(w http.ResponseWriter, r *http.Request, config *hunt.Config) {
+	categories := config.CategoryNames()
+	if category := r.URL.Query().Get("category"); category != "" {
+		if _, ok := config.Categories[category]; !ok {
+			writeUnknownCategory(w, category)
+			return
+		}
+		categories = []string{category}
+	}
+
+	engines := []APIEngine{}
+	for _, category := range categories {
+		for i, engine := range config.Categories[category] {
+			engines = append(engines, APIEngine{
+				Category: category,
+				Index:    i + 1,
+				Name:     engine.Name,
+				URL:      engine.URL,
+				Encoding: engine.Encoding,
+				Private:  engine.Private,
+			})
+		}
+	}
+	writeAPIResponse(w, http.StatusOK, map[string]any{"engines": engines})
+}
+
+// handleAPIBuild builds the search URLs for a query and selection
+func handleAPIBuild(w http.ResponseWriter, r *http.Request, config *hunt.Config) {
+	var req APIBuildRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, APIError{Code: apiErrInvalidRequest, Message: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		writeAPIError(w, http.StatusBadRequest, APIError{Code: apiErrInvalidRequest, Message: "query is required"})
+		return
+	}
+	if req.Category == "" {
+		req.Category = "search"
+	}
+
+	engines, ok := config.Categories[req.Category]
+	if !ok {
+		writeUnknownCategory(w, req.Category)
+		return
+	}
+
+	indices, err := apiSelections(req.Selections, engines)
+	if err != nil {
+		var selectionErr *apiSelectionError
+		if errors.As(err, &selectionErr) {
+			writeAPIError(w, http.StatusBadRequest, APIError{
+				Code:    apiErrInvalidSelection,
+				Message: fmt.Sprintf("selections match no engine in category %q", req.Category),
+				Details: selectionErr.invalid,
+			})
+			return
+		}
+		writeAPIError(w, http.StatusBadRequest, APIError{Code: apiErrInvalidSelection, Message: err.Error()})
+		return
+	}
+
+	results := make([]APIBuildResult, len(indices))
+	for i, idx := range indices {
+		engine := engines[idx]
+		search := hunt.BuildSearchRequest(engine, req.Query)
+		results[i] = APIBuildResult{Name: engine.Name, URL: search.URL, Private: engine.Private}
+		prepared := engine.PrepareQuery(req.Query)
+		if prepared.Query != req.Query {
+			results[i].Query = prepared.Query
+		}
+		results[i].Dropped = prepared.Dropped
+		if search.Method == hunt.MethodPost {
+			results[i].Method, results[i].Form, results[i].Charset = search.Method, search.Fields, search.Charset
+		}
+	}
+	writeAPIResponse(w, http.StatusOK, map[string]any{
+		"query":    req.Query,
+		"category": req.Category,
+		"results":  results,
+	})
+}
+
+// apiSelectionError lists selections that match no engine
+type apiSelectionError struct {
+	invalid []string
+}
+
+func (e *apiSelectionError) Error() string {
+	return fmt.Sprintf("invalid selections: %s", strings.Join(e.invalid, ", "))
+}
+
+// apiSelections resolves selections like the CLI does, but rejects invalid selections
+// instead of skipping them, since an API caller cannot see warnings
+func apiSelections(selections []string, engines []hunt.SearchEngine) ([]int, error) {
+	if len(selections) == 0 {
+		selections = []string{"all"}
+	}
+
+	var invalid []string
+	for _, selection := range selections {
+		if hunt.ResolveSelection(selection, engines) == hunt.SelectionInvalid {
+			invalid = append(invalid, selection)
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, &apiSelectionError{invalid: invalid}
+	}
+
+	return hunt.ParseSelections(nil, selections, engines)
+}
+
+// writeUnknownCategory reports a category that is not in the config
+func writeUnknownCategory(w http.ResponseWriter, category string) {
+	writeAPIError(w, http.StatusNotFound, APIError{
+		Code:    apiErrUnknownCategory,
+		Message: fmt.Sprintf("no services found for category %q", category),
+	})
+}
+
+// writeAPIError writes a structured error response
+func writeAPIError(w http.ResponseWriter, status int, apiErr APIError) {
+	writeAPIResponse(w, status, map[string]any{"error": apiErr})
+}
+
+// writeAPIResponse writes fields as a JSON object tagged with the API version
+func writeAPIResponse(w http.ResponseWriter, status int, fields map[string]any) {
+	fields["api_version"] = apiVersion
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(fields); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write API response: %v\n", err)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// apiResponse is the union of every v1 response shape, for decoding in tests
+type apiResponse struct {
+	APIVersion string           `json:"api_version"`
+	Categories []APICategory    `json:"categories"`
+	Engines    []APIEngine      `json:"engines"`
+	Results    []APIBuildResult `json:"results"`
+	Error      *APIError        `json:"error"`
+}
+
+func TestAPIHandler(t *testing.T) {
+	handler := newAPIHandler(newTestConfigWatcher(t, serveTestConfig))
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantError  string
+		wantAllow  string
+		check      func(t *testing.T, resp apiResponse)
+	}{
+		{
+			name: "categories", method: http.MethodGet, target: "/api/v1/categories", wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp apiResponse) {
+				if len(resp.Categories) != 2 || resp.Categories[0].Name != "search" || resp.Categories[0].EngineCount != 2 {
+					t.Errorf("categories = %+v", resp.Categories)
+				}
+			},
+		},
+		{
+			name: "engines in category", method: http.MethodGet, target: "/api/v1/engines?category=shop", wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp apiResponse) {
+				if len(resp.Engines) != 1 || resp.Engines[0].Name != "Swappa" || resp.Engines[0].Index != 1 || resp.Engines[0].Encoding.Delimiter != "%20" {
+					t.Errorf("engines = %+v", resp.Engines)
+				}
+			},
+		},
+		{
+			name: "all engines", method: http.MethodGet, target: "/api/v1/engines", wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp apiResponse) {
+				if len(resp.Engines) != 3 {
+					t.Errorf("got %d engines, want 3", len(resp.Engines))
+				}
+			},
+		},
+		{
+			name: "engines unknown category", method: http.MethodGet, target: "/api/v1/engines?category=nope",
+			wantStatus: http.StatusNotFound, wantError: apiErrUnknownCategory,
+		},
+		{
+			name: "build with selections", method: http.MethodPost, target: "/api/v1/build",
+			body:       `{"query": "machine learning", "selections": ["2", "bing", "Google"]}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp apiResponse) {
+				want := []string{"https://www.google.com/search?q=machine+learning", "https://www.bing.com/search?q=machine+learning"}
+				if len(resp.Results) != len(want) {
+					t.Fatalf("results = %+v, want %d", resp.Results, len(want))
+				}
+				for i, url := range want {
+					if resp.Results[i].URL != url {
+						t.Errorf("results[%d].URL = %q, want %q", i, resp.Results[i].URL, url)
+					}
+				}
+			},
+		},
+		{
+			name: "build all in category", method: http.MethodPost, target: "/api/v1/build",
+			body:       `{"query": "iphone 15", "category": "shop"}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp apiResponse) {
+				if len(resp.Results) != 1 || resp.Results[0].URL != "https://swappa.com/search?q=iphone%2015" {
+					t.Errorf("results = %+v", resp.Results)
+				}
+			},
+		},
+		{
+			name: "build invalid selection", method: http.MethodPost, target: "/api/v1/build",
+			body:       `{"query": "x", "selections": ["bing", "altavista", "9"]}`,
+			wantStatus: http.StatusBadRequest, wantError: apiErrInvalidSelection,
+			check: func(t *testing.T, resp apiResponse) {
+				if strings.Join(resp.Error.Details, ",") != "altavista,9" {
+					t.Errorf("error details = %v, want [altavista 9]", resp.Error.Details)
+				}
+			},
+		},
+		{
+			name: "build missing query", method: http.MethodPost, target: "/api/v1/build",
+			body: `{"category": "shop"}`, wantStatus: http.StatusBadRequest, wantError: apiErrInvalidRequest,
+		},
+		{
+			name: "build unknown field", method: http.MethodPost, target: "/api/v1/build",
+			body: `{"query": "x", "engines": ["bing"]}`, wantStatus: http.StatusBadRequest, wantError: apiErrInvalidRequest,
+		},
+		{
+			name: "build unknown category", method: http.MethodPost, target: "/api/v1/build",
+			body: `{"query": "x", "category": "nope"}`, wantStatus: http.StatusNotFound, wantError: apiErrUnknownCategory,
+		},
+		{
+			name: "unknown endpoint", method: http.MethodGet, target: "/api/v2/categories",
+			wantStatus: http.StatusNotFound, wantError: apiErrNotFound,
+		},
+		{
+			name: "wrong method", method: http.MethodGet, target: "/api/v1/build",
+			wantStatus: http.StatusMethodNotAllowed, wantError: apiErrMethodNotAllowed, wantAllow: "POST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d\n%s", tt.method, tt.target, rec.Code, tt.wantStatus, rec.Body)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Allow = %q, want %q", got, tt.wantAllow)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+
+			var resp apiResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not JSON: %v\n%s", err, rec.Body)
+			}
+			if resp.APIVersion != apiVersion {
+				t.Errorf("api_version = %q, want %q", resp.APIVersion, apiVersion)
+			}
+			if tt.wantError != "" && (resp.Error == nil || resp.Error.Code != tt.wantError) {
+				t.Errorf("error = %+v, want code %q", resp.Error, tt.wantError)
+			}
+			if tt.check != nil {
+				tt.check(t, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/aneely/hunt"
+)
+
+// exporters are the `hunt export` formats
+var exporters = map[string]func(args []string) int{
+	"opensearch": runExportOpenSearch,
+}
+
+// runExport implements `hunt export FORMAT ...`
+// Returns the process exit code
+func runExport(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		printExportUsage(os.Stdout)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	run, ok := exporters[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown export format %q\n", args[0])
+		printExportUsage(os.Stderr)
+		return exitUsage
+	}
+	return run(args[1:])
+}
+
+// printExportUsage lists the export formats
+func printExportUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s export FORMAT [OPTIONS] ...\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Formats (run with --help for details):\n")
+	fmt.Fprintf(w, "  opensearch ENGINE|CATEGORY   OpenSearch 1.1 description documents\n")
+}
+
+// runExportOpenSearch implements `hunt export opensearch ENGINE|CATEGORY`
+func runExportOpenSearch(args []string) int {
+	fs := flag.NewFlagSet("export opensearch", flag.ContinueOnError)
+	configPath := fs.String("config", "", "Config file to read (default: the search_engines.json hunt loads)")
+	outputDir := fs.String("output-dir", "", "Write one NAME.xml file per engine to this directory instead of stdout")
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s export opensearch [--config PATH] [--output-dir DIR] ENGINE|CATEGORY\n", os.Args[0])
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Writes an OpenSearch description for an engine, or for every engine in a category,\n")
+		fmt.Fprintf(w, "so it can be added to a browser. A single engine is written to stdout by default;\n")
+		fmt.Fprintf(w, "a category needs --output-dir.\n")
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Options:\n")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(os.Stderr, "Error: expected exactly one ENGINE or CATEGORY argument\n")
+		fs.Usage()
+		return exitUsage
+	}
+	name := positional[0]
+
+	var options []hunt.ConfigOption
+	if *configPath != "" {
+		options = append(options, hunt.WithFiles(*configPath))
+	}
+	config, err := hunt.LoadConfig(options...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfig
+	}
+
+	var engines []hunt.SearchEngine
+	if category, ok := config.Categories[name]; ok {
+		engines = category
+		if *outputDir == "" {
+			fmt.Fprintf(os.Stderr, "Error: %q is a category; use --output-dir to write one descriptor per engine\n", name)
+			return exitUsage
+		}
+	} else if selected, ok := config.FindBangEngine(name); ok {
+		engines = []hunt.SearchEngine{selected.Engine}
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: no engine or category named %q\n", name)
+		return exitUsage
+	}
+
+	for _, engine := range engines {
+		if delimiter := engine.Encoding.Delimiter; delimiter != "+" && delimiter != "%20" {
+			fmt.Fprintf(os.Stderr, "Warning: %s separates words with %q, which OpenSearch cannot express; browsers will use + or %%20\n", engine.Name, delimiter)
+		}
+		if engine.Encoding.Case != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s converts queries to %s case, which OpenSearch cannot express\n", engine.Name, engine.Encoding.Case)
+		}
+		desc := hunt.EngineOpenSearchDescription(engine)
+
+		if *outputDir == "" {
+			if err := hunt.WriteOpenSearchDescription(os.Stdout, desc); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return exitFailure
+			}
+			continue
+		}
+
+		path := filepath.Join(*outputDir, hunt.NormalizeEngineName(engine.Name)+".xml")
+		if err := writeOpenSearchFile(path, desc); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+	return exitOK
+}
+
+// writeOpenSearchFile writes desc to path, creating the directory if needed
+func writeOpenSearchFile(path string, desc hunt.OpenSearchDescription) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := hunt.WriteOpenSearchDescription(f, desc); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aneely/hunt"
+)
+
+func TestRunExportOpenSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		toDir     bool
+		wantExit  int
+		wantFiles []string
+	}{
+		{name: "category", target: "search", toDir: true, wantFiles: []string{"bing.xml", "google.xml"}},
+		{name: "engine", target: "swappa", toDir: true, wantFiles: []string{"swappa.xml"}},
+		{name: "category needs a directory", target: "search", wantExit: exitUsage},
+		{name: "unknown", target: "nope", toDir: true, wantExit: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath, _ := writeImportFixtures(t)
+			dir := filepath.Join(t.TempDir(), "out")
+			args := []string{"--config", configPath, tt.target}
+			if tt.toDir {
+				args = append(args, "--output-dir", dir)
+			}
+
+			if got := runExportOpenSearch(args); got != tt.wantExit {
+				t.Fatalf("runExportOpenSearch() = %d, want %d", got, tt.wantExit)
+			}
+
+			for _, name := range tt.wantFiles {
+				f, err := os.Open(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("missing descriptor: %v", err)
+				}
+				desc, err := hunt.ParseOpenSearchDescription(f)
+				f.Close()
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if _, err := desc.Engine(); err != nil {
+					t.Errorf("%s does not import back: %v", name, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aneely/hunt"
+)
+
+// importers are the `hunt import` sources
+var importers = map[string]func(args []string) int{
+	"bangs":      runImportBangs,
+	"opensearch": runImportOpenSearch,
+	"firefox":    runImportFirefox,
+	"chrome":     runImportChrome,
+}
+
+// runImport implements `hunt import SOURCE ...`
+// Returns the process exit code
+func runImport(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		printImportUsage(os.Stdout)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	run, ok := importers[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown import source %q\n", args[0])
+		printImportUsage(os.Stderr)
+		return exitUsage
+	}
+	return run(args[1:])
+}
+
+// printImportUsage lists the import sources
+func printImportUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s import SOURCE [OPTIONS] FILE|DIR\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Sources (run with --help for details):\n")
+	fmt.Fprintf(w, "  bangs FILE             DuckDuckGo bang.js database\n")
+	fmt.Fprintf(w, "  opensearch FILE.xml    OpenSearch 1.1 description document\n")
+	fmt.Fprintf(w, "  firefox PROFILE_DIR    Custom engines from a Firefox profile\n")
+	fmt.Fprintf(w, "  chrome FILE            Chrome search engine list (JSON) or bookmarks HTML with keywords\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Every source accepts --dry-run to preview additions and conflicts before writing.\n")
+}
+
+// importOptions are the flags every import source shares
+type importOptions struct {
+	configPath string
+	category   string
+	dryRun     bool
+}
+
+// addImportFlags registers the shared import flags on fs
+func addImportFlags(fs *flag.FlagSet, opts *importOptions) {
+	fs.StringVar(&opts.configPath, "config", "", "search_engines.json to update (default: the one hunt loads)")
+	fs.StringVar(&opts.category, "category", "search", "Category to add the engines to")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Preview what would be added, skipped and conflicting without writing anything")
+}
+
+// parseInterspersed parses fs, allowing flags before and after positional arguments
+// Returns the positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// parseImportArgs parses an import command's flags and its single FILE argument
+// On failure it returns a non-negative exit code
+func parseImportArgs(fs *flag.FlagSet, args []string) (string, int) {
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return "", flagExitCode(err)
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(os.Stderr, "Error: expected exactly one FILE argument\n")
+		fs.Usage()
+		return "", exitUsage
+	}
+	return positional[0], -1
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	return splitParams([]string{value})
+}
+
+// applyImport merges candidates into the config file and reports what was added, what
+// conflicted with existing engines and what was skipped. skipped lists entries the source
+// could not convert. Returns the process exit code.
+func applyImport(opts importOptions, candidates []hunt.SearchEngine, skipped []hunt.ImportSkip) int {
+	configPath := opts.configPath
+	if configPath == "" {
+		var err error
+		if configPath, err = hunt.ConfigPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitConfig
+		}
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read search_engines.json: %v\n", err)
+		return exitConfig
+	}
+	config, err := hunt.LoadConfig(hunt.WithBytes(data))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfig
+	}
+
+	added, conflicts := hunt.MergeEngines(config, candidates)
+
+	verb := "Added"
+	if opts.dryRun {
+		verb = "Would add"
+	}
+	fmt.Printf("%s %d engines to %q in %s\n", verb, len(added), opts.category, configPath)
+	for _, engine := range added {
+		fmt.Printf("  + %s  %s\n", engine.Name, engine.URL)
+	}
+	if len(conflicts) > 0 {
+		fmt.Printf("Conflicts with existing engines (%d):\n", len(conflicts))
+		for _, skip := range conflicts {
+			fmt.Printf("  ! %s: %s\n", skip.Name, skip.Reason)
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("Skipped %d entries:\n", len(skipped))
+		for _, skip := range skipped {
+			fmt.Printf("  - %s: %s\n", skip.Name, skip.Reason)
+		}
+	}
+
+	if opts.dryRun || len(added) == 0 {
+		return exitOK
+	}
+
+	updated, err := hunt.AppendEngines(data, opts.category, added)
+	if err == nil {
+		err = writeFileAtomic(configPath, updated)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to update %s: %v\n", configPath, err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// writeFileAtomic replaces path with data via a temporary file in the same directory,
+// keeping the original file's permissions, so readers such as `hunt serve` never see
+// a half-written config
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".search_engines-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// runImportBangs implements `hunt import bangs FILE`
+func runImportBangs(args []string) int {
+	fs := flag.NewFlagSet("import bangs", flag.ContinueOnError)
+	var opts importOptions
+	addImportFlags(fs, &opts)
+	ddgCategories := fs.String("ddg-category", "", "Only import bangs in these DuckDuckGo categories or subcategories (comma-separated)")
+	triggers := fs.String("triggers", "", "Only import these bang triggers (comma-separated, e.g. yt,gh)")
+	all := fs.Bool("all", false, "Import every bang in the file")
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s import bangs [OPTIONS] FILE\n", os.Args[0])
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Adds engines from a DuckDuckGo bang.js file. Only bangs whose {{{s}}} placeholder ends\n")
+		fmt.Fprintf(w, "the URL can be imported; the rest are reported as skipped, as are bangs whose name or\n")
+		fmt.Fprintf(w, "URL is already in search_engines.json. Choose bangs with --ddg-category or --triggers\n")
+		fmt.Fprintf(w, "(or --all).\n")
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Options:\n")
+		fs.PrintDefaults()
+	}
+	path, code := parseImportArgs(fs, args)
+	if code >= 0 {
+		return code
+	}
+
+	categoryFilter := splitList(*ddgCategories)
+	triggerFilter := make(map[string]bool)
+	for _, trigger := range splitList(*triggers) {
+		triggerFilter[strings.ToLower(strings.TrimPrefix(trigger, "!"))] = false
+	}
+	if len(categoryFilter) == 0 && len(triggerFilter) == 0 && !*all {
+		fmt.Fprintf(os.Stderr, "Error: choose bangs with --ddg-category or --triggers, or pass --all to import every bang\n")
+		return exitUsage
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	defer f.Close()
+	bangs, err := hunt.ParseDuckDuckGoBangs(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	var candidates []hunt.SearchEngine
+	var skipped []hunt.ImportSkip
+	for _, bang := range bangs {
+		trigger := strings.ToLower(bang.Trigger)
+		if len(triggerFilter) > 0 {
+			if _, ok := triggerFilter[trigger]; !ok {
+				continue
+			}
+			triggerFilter[trigger] = true
+		}
+		if !bang.InCategory(categoryFilter) {
+			continue
+		}
+
+		engine, err := bang.Engine()
+		if err != nil {
+			skipped = append(skipped, hunt.ImportSkip{Name: fmt.Sprintf("%s (!%s)", bang.Name, bang.Trigger), Reason: err.Error()})
+			continue
+		}
+		candidates = append(candidates, engine)
+	}
+	for _, trigger := range splitList(*triggers) {
+		if found := triggerFilter[strings.ToLower(strings.TrimPrefix(trigger, "!"))]; !found {
+			skipped = append(skipped, hunt.ImportSkip{Name: "!" + strings.TrimPrefix(trigger, "!"), Reason: "no such bang in " + path})
+		}
+	}
+
+	return applyImport(opts, candidates, skipped)
+}
+
+// runImportOpenSearch implements `hunt import opensearch FILE.xml`
+func runImportOpenSearch(args []string) int {
+	fs := flag.NewFlagSet("import opensearch", flag.ContinueOnError)
+	var opts importOptions
+	addImportFlags(fs, &opts)
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s import opensearch [OPTIONS] FILE.xml\n", os.Args[0])
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Adds the engine described by an OpenSearch 1.1 description document. The text/html\n")
+		fmt.Fprintf(w, "Url template must end with {searchTerms}; a legacy InputEncoding becomes the engine's charset.\n")
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Options:\n")
+		fs.PrintDefaults()
+	}
+	path, code := parseImportArgs(fs, args)
+	if code >= 0 {
+		return code
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	defer f.Close()
+	desc, err := hunt.ParseOpenSearchDescription(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	engine, err := desc.Engine()
+	if err != nil {
+		name := desc.ShortName
+		if name == "" {
+			name = path
+		}
+		return applyImport(opts, nil, []hunt.ImportSkip{{Name: name, Reason: err.Error()}})
+	}
+	return applyImport(opts, []hunt.SearchEngine{engine}, nil)
+}
+
+// runImportFirefox implements `hunt import firefox PROFILE_DIR`
+func runImportFirefox(args []string) int {
+	fs := flag.NewFlagSet("import firefox", flag.ContinueOnError)
+	var opts importOptions
+	addImportFlags(fs, &opts)
+	includeBuiltIn := fs.Bool("include-builtin", false, "Also import the engines that ship with Firefox")
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s import firefox [OPTIONS] PROFILE_DIR\n", os.Args[0])
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Adds the custom search engines from a Firefox profile's %s (the\n", hunt.FirefoxSearchFile)
+		fmt.Fprintf(w, "file itself may be given instead of the profile directory). Engines whose URL template\n")
+		fmt.Fprintf(w, "hunt can't represent ({searchTerms} mid-URL, unknown charsets) are reported.\n")
+		fmt.Fprintf(w, "Close Firefox first so the file is up to date.\n")
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Options:\n")
+		fs.PrintDefaults()
+	}
+	path, code := parseImportArgs(fs, args)
+	if code >= 0 {
+		return code
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, hunt.FirefoxSearchFile)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	defer f.Close()
+	engines, err := hunt.ParseFirefoxSearchEngines(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	var candidates []hunt.SearchEngine
+	var skipped []hunt.ImportSkip
+	builtIn := 0
+	for _, ffEngine := range engines {
+		if ffEngine.BuiltIn() && !*includeBuiltIn {
+			builtIn++
+			continue
+		}
+		engine, err := ffEngine.Engine()
+		if err != nil {
+			skipped = append(skipped, hunt.ImportSkip{Name: ffEngine.Name, Reason: err.Error()})
+			continue
+		}
+		candidates = append(candidates, engine)
+	}
+	if builtIn > 0 {
+		fmt.Printf("Ignoring %d built-in Firefox engines (use --include-builtin to import them)\n", builtIn)
+	}
+
+	return applyImport(opts, candidates, skipped)
+}
+
+// runImportChrome implements `hunt import chrome FILE`
+func runImportChrome(args []string) int {
+	fs := flag.NewFlagSet("import chrome", flag.ContinueOnError)
+	var opts importOptions
+	addImportFlags(fs, &opts)
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s import chrome [OPTIONS] FILE\n", os.Args[0])
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Adds keyword searches from either:\n")
+		fmt.Fprintf(w, "  - a JSON list of {\"name\", \"keyword\", \"url\"} objects, as exported from Chrome's\n")
+		fmt.Fprintf(w, "    site search settings, with %%s (or {searchTerms}) marking the query\n")
+		fmt.Fprintf(w, "  - a bookmarks HTML export, where bookmarks with a keyword (SHORTCUTURL) and %%s in\n")
+		fmt.Fprintf(w, "    the URL are searches\n")
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Options:\n")
+		fs.PrintDefaults()
+	}
+	path, code := parseImportArgs(fs, args)
+	if code >= 0 {
+		return code
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	defer f.Close()
+	searches, err := hunt.ParseChromeSearchEngines(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	var candidates []hunt.SearchEngine
+	var skipped []hunt.ImportSkip
+	for _, search := range searches {
+		engine, err := search.Engine()
+		if err != nil {
+			name := search.Name
+			if search.Keyword != "" {
+				name = fmt.Sprintf("%s (%s)", search.Name, search.Keyword)
+			}
+			skipped = append(skipped, hunt.ImportSkip{Name: name, Reason: err.Error()})
+			continue
+		}
+		candidates = append(candidates, engine)
+	}
+
+	return applyImport(opts, candidates, skipped)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aneely/hunt"
+)
+
+const importTestBangs = `[
+	{"c": "Multimedia", "s": "YouTube", "sc": "Video", "t": "yt", "u": "https://www.youtube.com/results?search_query={{{s}}}"},
+	{"c": "Multimedia", "s": "Vimeo", "sc": "Video", "t": "vimeo", "u": "https://vimeo.com/search?q={{{s}}}&sort=date"},
+	{"c": "Tech", "s": "Go Packages", "sc": "Programming", "t": "gopkg", "u": "https://pkg.go.dev/search?q={{{s}}}"},
+	{"c": "Online Services", "s": "Bing", "sc": "Search", "t": "b", "u": "https://www.bing.com/search?q={{{s}}}"}
+]`
+
+// writeImportFixtures writes a config and a bang.js file, returning their paths
+func writeImportFixtures(t *testing.T) (configPath, bangsPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	configPath = filepath.Join(dir, "search_engines.json")
+	bangsPath = filepath.Join(dir, "bang.js")
+	if err := os.WriteFile(configPath, []byte(serveTestConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bangsPath, []byte(importTestBangs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return configPath, bangsPath
+}
+
+func TestRunImportBangs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantExit  int
+		wantNames map[string][]string // category -> engine names after the import
+	}{
+		{
+			name:      "by DuckDuckGo category",
+			args:      []string{"--ddg-category", "multimedia", "--category", "video"},
+			wantNames: map[string][]string{"video": {"YouTube"}, "search": {"Bing", "Google"}},
+		},
+		{
+			name:      "by trigger",
+			args:      []string{"--triggers", "!gopkg,b"},
+			wantNames: map[string][]string{"search": {"Bing", "Google", "Go Packages"}},
+		},
+		{
+			name:      "dry run writes nothing",
+			args:      []string{"--all", "--dry-run"},
+			wantNames: map[string][]string{"search": {"Bing", "Google"}},
+		},
+		{
+			name:     "no filter",
+			args:     []string{},
+			wantExit: exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath, bangsPath := writeImportFixtures(t)
+			args := append([]string{bangsPath, "--config", configPath}, tt.args...)
+
+			if got := runImportBangs(args); got != tt.wantExit {
+				t.Fatalf("runImportBangs() = %d, want %d", got, tt.wantExit)
+			}
+
+			config, err := hunt.LoadConfig(hunt.WithFiles(configPath))
+			if err != nil {
+				t.Fatalf("config is invalid after import: %v", err)
+			}
+			for category, want := range tt.wantNames {
+				var names []string
+				for _, engine := range config.GetEnginesByCategory(category) {
+					names = append(names, engine.Name)
+				}
+				if len(names) != len(want) {
+					t.Errorf("%s engines = %v, want %v", category, names, want)
+					continue
+				}
+				for i := range want {
+					if names[i] != want[i] {
+						t.Errorf("%s engines = %v, want %v", category, names, want)
+						break
+					}
+				}
+			}
+
+			if info, err := os.Stat(configPath); err == nil && info.Mode().Perm() != 0600 {
+				t.Errorf("import changed config permissions to %v", info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestRunImportOpenSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		xml        string
+		wantSearch []string
+	}{
+		{
+			name:       "adds the engine",
+			xml:        `<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/"><ShortName>Wikipedia</ShortName><Url type="text/html" template="https://en.wikipedia.org/w/index.php?search={searchTerms}"/></OpenSearchDescription>`,
+			wantSearch: []string{"Bing", "Google", "Wikipedia"},
+		},
+		{
+			name:       "skips duplicates",
+			xml:        `<OpenSearchDescription><ShortName>Bing Search</ShortName><Url type="text/html" template="https://www.bing.com/search?q={searchTerms}"/></OpenSearchDescription>`,
+			wantSearch: []string{"Bing", "Google"},
+		},
+		{
+			name:       "skips unsupported descriptors",
+			xml:        `<OpenSearchDescription><ShortName>Poster</ShortName><Url type="text/html" method="post" template="https://example.com/?q={searchTerms}"/></OpenSearchDescription>`,
+			wantSearch: []string{"Bing", "Google"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath, _ := writeImportFixtures(t)
+			xmlPath := filepath.Join(t.TempDir(), "engine.xml")
+			if err := os.WriteFile(xmlPath, []byte(tt.xml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := runImportOpenSearch([]string{"--config", configPath, xmlPath}); got != exitOK {
+				t.Fatalf("runImportOpenSearch() = %d, want %d", got, exitOK)
+			}
+
+			assertSearchEngines(t, configPath, tt.wantSearch)
+		})
+	}
+}
+
+// mozLz4Literals wraps data in a mozLz4 file whose LZ4 block is a single literal run
+func mozLz4Literals(data string) []byte {
+	out := []byte("mozLz40\x00")
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(data)))
+	out = append(out, 0xf0)
+	for n := len(data) - 15; ; n -= 255 {
+		if n < 255 {
+			out = append(out, byte(n))
+			break
+		}
+		out = append(out, 255)
+	}
+	return append(out, data...)
+}
+
+func TestRunImportFirefox(t *testing.T) {
+	const searchJSON = `{"version": 6, "engines": [
+		{"_name": "Google", "_isAppProvided": true, "_urls": [{"template": "https://www.google.com/search?q={searchTerms}"}]},
+		{"_name": "Bing Copy", "_loadPath": "[user]", "_urls": [{"template": "https://www.bing.com/search?q={searchTerms}"}]},
+		{"_name": "Go Packages", "_loadPath": "[user]", "_urls": [{"template": "https://pkg.go.dev/search?q={searchTerms}"}]},
+		{"_name": "Poster", "_loadPath": "[user]", "_urls": [{"method": "POST", "template": "https://example.com/search"}]}
+	]}`
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantSearch []string
+	}{
+		{name: "custom engines", wantSearch: []string{"Bing", "Google", "Go Packages"}},
+		{name: "preview writes nothing", args: []string{"--dry-run"}, wantSearch: []string{"Bing", "Google"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath, _ := writeImportFixtures(t)
+			profile := t.TempDir()
+			if err := os.WriteFile(filepath.Join(profile, hunt.FirefoxSearchFile), mozLz4Literals(searchJSON), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			args := append([]string{"--config", configPath, profile}, tt.args...)
+			if got := runImportFirefox(args); got != exitOK {
+				t.Fatalf("runImportFirefox() = %d, want %d", got, exitOK)
+			}
+			assertSearchEngines(t, configPath, tt.wantSearch)
+		})
+	}
+}
+
+func TestRunImportChrome(t *testing.T) {
+	const bookmarks = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://pkg.go.dev/search?q=%s" SHORTCUTURL="go">Go Packages</A>
+    <DT><A HREF="https://www.google.com/search?q=%s" SHORTCUTURL="g">Google</A>
+    <DT><A HREF="https://go.dev/">Go</A>
+</DL><p>`
+
+	configPath, _ := writeImportFixtures(t)
+	path := filepath.Join(t.TempDir(), "bookmarks.html")
+	if err := os.WriteFile(path, []byte(bookmarks), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := runImportChrome([]string{"--config", configPath, path}); got != exitOK {
+		t.Fatalf("runImportChrome() = %d, want %d", got, exitOK)
+	}
+	assertSearchEngines(t, configPath, []string{"Bing", "Google", "Go Packages"})
+}
+
+// assertSearchEngines checks the "search" engine names in the config at configPath
+func assertSearchEngines(t *testing.T, configPath string, want []string) {
+	t.Helper()
+	config, err := hunt.LoadConfig(hunt.WithFiles(configPath))
+	if err != nil {
+		t.Fatalf("config is invalid after import: %v", err)
+	}
+	var names []string
+	for _, engine := range config.GetEnginesByCategory("search") {
+		names = append(names, engine.Name)
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("search engines = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,605 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	buildOnce   sync.Once
+	builtBinary string
+	buildErr    error
+)
+
+// TestMain removes the shared test binary once every test has run
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if builtBinary != "" {
+		os.RemoveAll(filepath.Dir(builtBinary))
+	}
+	os.Exit(code)
+}
+
+// huntBinary builds the command once per test run and returns its path
+func huntBinary(t *testing.T) string {
+	t.Helper()
+	buildOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "hunt-test")
+		if err != nil {
+			buildErr = err
+			return
+		}
+		builtBinary = filepath.Join(dir, "hunt-test")
+		if output, err := exec.Command("go", "build", "-o", builtBinary, ".").CombinedOutput(); err != nil {
+			buildErr = fmt.Errorf("%v\nOutput: %s", err, output)
+		}
+	})
+	if buildErr != nil {
+		t.Fatalf("Failed to build binary: %v", buildErr)
+	}
+	return builtBinary
+}
+
+// writeConfig writes json as search_engines.json in a new temporary directory and returns the directory
+func writeConfig(t *testing.T, json string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "search_engines.json"), []byte(json), 0444); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+	return dir
+}
+
+// TestIntegration_ExitCodes tests that the binary exits with correct exit codes
+// for different scenarios (help flags, missing arguments, etc.) and verifies
+// that output is routed to the correct stream (stdout vs stderr).
+func TestIntegration_ExitCodes(t *testing.T) {
+	binaryPath := huntBinary(t)
+
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Test", "url": "https://test.com/search?q=", "space_delimiter": "+"}
+		]
+	}`)
+
+	tests := []struct {
+		name           string
+		args           []string
+		wantExitCode   int
+		wantInStdout   []string
+		wantInStderr   []string
+	}{
+		{
+			name:         "help flag --help exits with 0 and writes to stdout",
+			args:         []string{"--help"},
+			wantExitCode: 0,
+			wantInStdout: []string{"Usage:", "Options:", "-h, --help"},
+			wantInStderr: []string{},
+		},
+		{
+			name:         "help flag -h exits with 0 and writes to stdout",
+			args:         []string{"-h"},
+			wantExitCode: 0,
+			wantInStdout: []string{"Usage:", "Options:", "-h, --help"},
+			wantInStderr: []string{},
+		},
+		{
+			name:         "no arguments exits with 2 and writes to stderr",
+			args:         []string{},
+			wantExitCode: 2,
+			wantInStdout: []string{},
+			wantInStderr: []string{"Usage:"},
+		},
+		{
+			name:         "help flag with subcommand exits with 0 and writes to stdout",
+			args:         []string{"shop", "--help"},
+			wantExitCode: 0,
+			wantInStdout: []string{"Usage:", "shop"},
+			wantInStderr: []string{},
+		},
+		{
+			name:         "--remote with --page exits with 2 and writes to stderr",
+			args:         []string{"--remote", "--page", "query"},
+			wantExitCode: 2,
+			wantInStdout: []string{},
+			wantInStderr: []string{"Cannot use --page with --remote"},
+		},
+		{
+			name:         "command failure exits with 5 and writes to stderr",
+			args:         []string{"export", "opensearch", "--output-dir", filepath.Join(tmpDir, "search_engines.json", "out"), "Test"},
+			wantExitCode: 5,
+			wantInStdout: []string{},
+			wantInStderr: []string{"Error:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run the binary with the given args
+			cmd := exec.Command(binaryPath, tt.args...)
+			cmd.Dir = tmpDir // Set working directory so it finds search_engines.json
+
+			// Capture stdout and stderr separately
+			var stdout, stderr strings.Builder
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+
+			// Get exit code
+			exitCode := 0
+			if err != nil {
+				if exitErr, ok := err.(*exec.ExitError); ok {
+					exitCode = exitErr.ExitCode()
+				} else {
+					t.Fatalf("Failed to run command: %v", err)
+				}
+			}
+
+			stdoutStr := stdout.String()
+			stderrStr := stderr.String()
+
+			// Check exit code
+			if exitCode != tt.wantExitCode {
+				t.Errorf("Exit code = %d, want %d\nStdout: %s\nStderr: %s",
+					exitCode, tt.wantExitCode, stdoutStr, stderrStr)
+			}
+
+			// Check stdout content
+			for _, want := range tt.wantInStdout {
+				if !strings.Contains(stdoutStr, want) {
+					t.Errorf("Stdout missing expected string %q\nGot: %s", want, stdoutStr)
+				}
+			}
+
+			// Verify stdout doesn't contain content meant for stderr
+			if len(tt.wantInStderr) > 0 && len(tt.wantInStdout) > 0 {
+				// If we expect stderr content, stdout should not contain it
+				for _, stderrContent := range tt.wantInStderr {
+					if strings.Contains(stdoutStr, stderrContent) {
+						t.Errorf("Stdout incorrectly contains stderr content %q\nStdout: %s",
+							stderrContent, stdoutStr)
+					}
+				}
+			}
+
+			// Check stderr content
+			for _, want := range tt.wantInStderr {
+				if !strings.Contains(stderrStr, want) {
+					t.Errorf("Stderr missing expected string %q\nGot: %s", want, stderrStr)
+				}
+			}
+
+			// Verify stderr doesn't contain content meant for stdout
+			if len(tt.wantInStdout) > 0 && len(tt.wantInStderr) > 0 {
+				// If we expect stdout content, stderr should not contain it
+				for _, stdoutContent := range tt.wantInStdout {
+					if strings.Contains(stderrStr, stdoutContent) {
+						t.Errorf("Stderr incorrectly contains stdout content %q\nStderr: %s",
+							stdoutContent, stderrStr)
+					}
+				}
+			}
+
+			// Verify expected empty streams are actually empty
+			if len(tt.wantInStdout) == 0 && stdoutStr != "" {
+				t.Errorf("Expected empty stdout, got: %s", stdoutStr)
+			}
+			if len(tt.wantInStderr) == 0 && stderrStr != "" {
+				t.Errorf("Expected empty stderr, got: %s", stderrStr)
+			}
+		})
+	}
+}
+
+// TestIntegration_PrivateWithoutBrowser tests that --private fails clearly
+// instead of falling back to a normal tab when no private browser is found
+func TestIntegration_PrivateWithoutBrowser(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("private browser lookup uses PATH only on Linux and other Unix systems")
+	}
+
+	binaryPath := huntBinary(t)
+
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Test", "url": "https://test.com/search?q=", "space_delimiter": "+"},
+			{"name": "Secret", "url": "https://secret.com/search?q=", "private": true}
+		]
+	}`)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStderr string
+	}{
+		{
+			name:       "private flag",
+			args:       []string{"--private", "query"},
+			wantStderr: "private window",
+		},
+		{
+			name:       "engine requires private window",
+			args:       []string{"-s", "Secret", "query"},
+			wantStderr: "Secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// An empty PATH guarantees neither a private browser nor xdg-open is found
+			cmd := exec.Command(binaryPath, tt.args...)
+			cmd.Dir = tmpDir
+			cmd.Env = append(os.Environ(), "PATH="+t.TempDir(), "HUNT_TEST_MODE=1", "DISPLAY=:0")
+
+			var stdout, stderr strings.Builder
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			exitErr, ok := err.(*exec.ExitError)
+			if !ok || exitErr.ExitCode() != 1 {
+				t.Fatalf("Run() error = %v, want exit code 1\nStderr: %s", err, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Stderr missing %q\nGot: %s", tt.wantStderr, stderr.String())
+			}
+			if strings.Contains(stdout.String(), "Opening") {
+				t.Errorf("Stdout shows URLs being opened, want none\nGot: %s", stdout.String())
+			}
+		})
+	}
+}
+
+// TestIntegration_OpenFailureExitCodes tests that failures to open URLs are
+// summarized and reported through distinct exit codes
+func TestIntegration_OpenFailureExitCodes(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake opener relies on xdg-open, which is only used on Linux")
+	}
+
+	// The shared binary lives apart from the config, so a missing config can be tested via the working directory
+	binaryPath := huntBinary(t)
+
+	// Fake xdg-open that fails for any URL on a "broken" host
+	binDir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in *broken*) exit 3 ;; esac\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(binDir, "xdg-open"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake xdg-open: %v", err)
+	}
+
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Working", "url": "https://working.example/search?q="},
+			{"name": "Broken", "url": "https://broken.example/search?q="}
+		],
+		"shop": [
+			{"name": "Broken Shop", "url": "https://broken.example/shop?q="}
+		]
+	}`)
+
+	tests := []struct {
+		name         string
+		dir          string
+		args         []string
+		wantExitCode int
+		wantInStdout []string
+		wantInStderr []string
+	}{
+		{
+			name:         "all opened",
+			dir:          tmpDir,
+			args:         []string{"-s", "Working", "query"},
+			wantExitCode: 0,
+			wantInStdout: []string{"Opened: 1, Failed: 0"},
+		},
+		{
+			name:         "partial failure",
+			dir:          tmpDir,
+			args:         []string{"query"},
+			wantExitCode: 4,
+			wantInStdout: []string{"Opened: 1, Failed: 1"},
+			wantInStderr: []string{"Failed to open:", "Broken"},
+		},
+		{
+			name:         "total failure",
+			dir:          tmpDir,
+			args:         []string{"shop", "query"},
+			wantExitCode: 1,
+			wantInStdout: []string{"Opened: 0, Failed: 1"},
+			wantInStderr: []string{"Broken Shop"},
+		},
+		{
+			name:         "invalid selection is a usage error",
+			dir:          tmpDir,
+			args:         []string{"-s", "Nonexistent", "--", "query"},
+			wantExitCode: 2,
+		},
+		{
+			name:         "invalid flag value is a usage error",
+			dir:          tmpDir,
+			args:         []string{"--strategy", "warp", "query"},
+			wantExitCode: 2,
+			wantInStderr: []string{"unknown open strategy"},
+		},
+		{
+			name:         "missing config is a config error",
+			dir:          t.TempDir(),
+			args:         []string{"query"},
+			wantExitCode: 3,
+			wantInStderr: []string{"search_engines.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binaryPath, tt.args...)
+			cmd.Dir = tt.dir
+			cmd.Env = append(os.Environ(), "PATH="+binDir, "HUNT_TEST_MODE=1", "DISPLAY=:0")
+
+			var stdout, stderr strings.Builder
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			exitCode := 0
+			if err := cmd.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("Failed to run command: %v", err)
+				}
+				exitCode = exitErr.ExitCode()
+			}
+
+			if exitCode != tt.wantExitCode {
+				t.Errorf("Exit code = %d, want %d\nStdout: %s\nStderr: %s",
+					exitCode, tt.wantExitCode, stdout.String(), stderr.String())
+			}
+			for _, want := range tt.wantInStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Stdout missing expected string %q\nGot: %s", want, stdout.String())
+				}
+			}
+			for _, want := range tt.wantInStderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("Stderr missing expected string %q\nGot: %s", want, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+// TestIntegration_RemoteMode tests that --remote prints links instead of
+// launching any opener
+func TestIntegration_RemoteMode(t *testing.T) {
+	binaryPath := huntBinary(t)
+
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Bing", "url": "https://www.bing.com/search?q="},
+			{"name": "Secret", "url": "https://secret.com/search?q=", "private": true}
+		]
+	}`)
+
+	// An empty PATH means any attempt to launch an opener or browser would fail
+	cmd := exec.Command(binaryPath, "--remote", "remote query")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "PATH="+t.TempDir(), "HUNT_TEST_MODE=1", "DISPLAY=:0")
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Run() error = %v\nStderr: %s", err, stderr.String())
+	}
+
+	for _, want := range []string{
+		"https://www.bing.com/search?q=remote+query",
+		"https://secret.com/search?q=remote+query (private)",
+		"Total services used: 2",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Stdout missing %q\nGot: %s", want, stdout.String())
+		}
+	}
+	// Output is not a terminal, so no escape sequences should be written
+	if strings.Contains(stdout.String(), "\x1b") {
+		t.Errorf("Stdout contains escape sequences when not a terminal\nGot: %q", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "Opening") {
+		t.Errorf("Stdout shows URLs being opened in remote mode\nGot: %s", stdout.String())
+	}
+}
+
+// TestIntegration_LandingPage tests that --page opens a single landing page
+// that links to every selected service
+func TestIntegration_LandingPage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake opener relies on xdg-open, which is only used on Linux")
+	}
+
+	binaryPath := huntBinary(t)
+
+	// Fake xdg-open that records what it was asked to open
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "opened.log")
+	script := "#!/bin/sh\necho \"$1\" >> " + logPath + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "xdg-open"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake xdg-open: %v", err)
+	}
+
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Bing", "url": "https://www.bing.com/search?q="},
+			{"name": "Google", "url": "https://www.google.com/search?q="}
+		]
+	}`)
+
+	cmd := exec.Command(binaryPath, "--page", "landing query")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "PATH="+binDir, "HUNT_TEST_MODE=1", "DISPLAY=:0", "TMPDIR="+t.TempDir())
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Run() error = %v\nStderr: %s", err, stderr.String())
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read opener log: %v", err)
+	}
+	opened := strings.Fields(string(data))
+	if len(opened) != 1 || !strings.HasSuffix(opened[0], ".html") {
+		t.Fatalf("Opened %v, want exactly one .html landing page", opened)
+	}
+
+	page, err := os.ReadFile(opened[0])
+	if err != nil {
+		t.Fatalf("Failed to read landing page: %v", err)
+	}
+	for _, want := range []string{
+		"https://www.bing.com/search?q=landing&#43;query",
+		"https://www.google.com/search?q=landing&#43;query",
+	} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("Landing page missing link %q", want)
+		}
+	}
+	if !strings.Contains(stdout.String(), "Total services used: 2") {
+		t.Errorf("Stdout missing service count\nGot: %s", stdout.String())
+	}
+}
+
+// TestIntegration_Bangs tests that !name tokens select engines across categories
+// and are removed from the search term, unless --no-bangs is given
+func TestIntegration_Bangs(t *testing.T) {
+	binaryPath := huntBinary(t)
+
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Bing", "url": "https://www.bing.com/search?q="},
+			{"name": "YouTube", "url": "https://www.youtube.com/results?search_query="}
+		],
+		"technews": [
+			{"name": "Hacker News", "url": "https://hn.algolia.com/?q=", "space_delimiter": "%20"}
+		]
+	}`)
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantExit    int
+		wantStdout  []string
+		wantMissing []string
+		wantStderr  string
+	}{
+		{
+			name: "bangs select engines across categories",
+			args: []string{"--remote", "!youtube rust !HackerNews async"},
+			wantStdout: []string{
+				"https://www.youtube.com/results?search_query=rust+async",
+				"https://hn.algolia.com/?q=rust%20async",
+				"Total services used: 2",
+			},
+			wantMissing: []string{"bing.com"},
+		},
+		{
+			name:       "unknown bang stays in the query",
+			args:       []string{"--remote", "!nope rust"},
+			wantStdout: []string{"https://www.bing.com/search?q=%21nope+rust", "Total services used: 2"},
+			wantStderr: "Unknown bang '!nope'",
+		},
+		{
+			name:        "no-bangs searches literally",
+			args:        []string{"--remote", "--no-bangs", "!youtube rust"},
+			wantStdout:  []string{"https://www.bing.com/search?q=%21youtube+rust", "Total services used: 2"},
+			wantMissing: []string{"hn.algolia.com"},
+		},
+		{
+			name:       "bangs cannot be combined with -s",
+			args:       []string{"--remote", "-s", "bing", "!youtube rust"},
+			wantExit:   2,
+			wantStderr: "Cannot combine bangs",
+		},
+		{
+			name:       "bangs without a search term",
+			args:       []string{"--remote", "!youtube"},
+			wantExit:   2,
+			wantStderr: "No search term left",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binaryPath, tt.args...)
+			cmd.Dir = tmpDir
+			cmd.Env = append(os.Environ(), "PATH="+t.TempDir(), "HUNT_TEST_MODE=1", "DISPLAY=:0")
+
+			var stdout, stderr strings.Builder
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			exitCode := 0
+			if err := cmd.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					t.Fatalf("Run() error = %v", err)
+				}
+				exitCode = exitErr.ExitCode()
+			}
+
+			if exitCode != tt.wantExit {
+				t.Fatalf("Exit code = %d, want %d\nStderr: %s", exitCode, tt.wantExit, stderr.String())
+			}
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Stdout missing %q\nGot: %s", want, stdout.String())
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(stdout.String(), unwanted) {
+					t.Errorf("Stdout unexpectedly contains %q\nGot: %s", unwanted, stdout.String())
+				}
+			}
+			if tt.wantStderr != "" && !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Stderr missing %q\nGot: %s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+// TestIntegration_RedirectUnknownDefault tests that the redirector refuses to start with a
+// --default engine that isn't in the config
+func TestIntegration_RedirectUnknownDefault(t *testing.T) {
+	binaryPath := huntBinary(t)
+	tmpDir := writeConfig(t, `{
+		"search": [
+			{"name": "Bing", "url": "https://www.bing.com/search?q="}
+		]
+	}`)
+
+	cmd := exec.Command(binaryPath, "redirect", "--addr", "127.0.0.1:0", "--default", "altavista")
+	cmd.Dir = tmpDir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("Run() error = %v, want exit code 2", err)
+	}
+	if exitErr.ExitCode() != 2 {
+		t.Errorf("Exit code = %d, want 2\nStderr: %s", exitErr.ExitCode(), stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `default engine "altavista" not found`) {
+		t.Errorf("Stderr = %q, want it to name the unknown default engine", stderr.String())
+	}
+}
@@ -0,0 +1,849 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aneely/hunt"
+)
+
+// Exit codes, documented in printUsage
+const (
+	exitOK             = 0   // Every selected service opened
+	exitOpenFailed     = 1   // No service could be opened
+	exitUsage          = 2   // Invalid arguments, flags or selections
+	exitConfig         = 3   // search_engines.json is missing or invalid
+	exitPartialFailure = 4   // Some services opened, others failed
+	exitFailure        = 5   // A command such as serve or import failed at runtime (e.g., address in use)
+	exitInterrupted    = 130 // Interrupted (Ctrl-C) before every service was opened
+)
+
+// commands are the non-search subcommands; each receives the remaining arguments
+// and returns the process exit code
+var commands = map[string]func(args []string) int{
+	"serve":    runServe,
+	"redirect": runRedirect,
+	"api":      runAPI,
+	"import":   runImport,
+	"export":   runExport,
+}
+
+func main() {
+	// Commands have their own flags and help, so dispatch before anything else
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
+	// Check for help flag first
+	for _, arg := range os.Args[1:] {
+		if arg == "-h" || arg == "--help" {
+			printUsage(os.Stdout)
+			os.Exit(exitOK)
+		}
+	}
+
+	// Check for subcommand BEFORE parsing flags (for backward compatibility)
+	// Subcommands come before flags: hunt shop -i "laptop"
+	var category string
+	categoryExplicitlySet := false
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		// First argument is not a flag - check if it's a subcommand
+		subcommand := strings.ToLower(os.Args[1])
+		mappedCategory := mapSubcommandToCategory(subcommand)
+		if mappedCategory != "" {
+			category = mappedCategory
+			categoryExplicitlySet = true
+			// Remove subcommand from os.Args so flag.Parse() works normally
+			os.Args = append(os.Args[:1], os.Args[2:]...)
+		}
+	}
+
+	// Default to "search" category if no subcommand (for non-interactive mode)
+	if category == "" {
+		category = "search"
+	}
+
+	// Parse flags
+	interactive := flag.Bool("i", false, "Interactive mode to select search engines")
+	interactiveLong := flag.Bool("interactive", false, "Interactive mode to select search engines")
+	servicesFlag := flag.Bool("s", false, "Specify search engines by number or name")
+	servicesFlagLong := flag.Bool("services", false, "Specify search engines by number or name")
+	privateFlag := flag.Bool("private", false, "Open URLs in a private/incognito browser window")
+	remoteFlag := flag.Bool("remote", false, "Print clickable links instead of opening a browser")
+	copyFlag := flag.Bool("copy", false, "Copy the URLs to the local clipboard via the terminal (OSC 52)")
+	pageFlag := flag.Bool("page", false, "Open a single landing page linking every service instead of one tab each")
+	noBangs := flag.Bool("no-bangs", false, "Treat !name tokens in the search term as plain text")
+	vertical := flag.String("vertical", "", "Search a vertical instead: images, videos, news, maps or scholar")
+	verticalFallback := flag.Bool("vertical-fallback", false, "With --vertical, use the main search of services that lack the vertical")
+	var searchFlags searchOptionFlags
+	flag.StringVar(&searchFlags.since, "since", "", "Only results from this date on (YYYY-MM-DD)")
+	flag.StringVar(&searchFlags.until, "until", "", "Only results up to this date (YYYY-MM-DD)")
+	flag.StringVar(&searchFlags.past, "past", "", "Only results from the past hour, day, week, month or year")
+	flag.StringVar(&searchFlags.lang, "lang", "", "Results in this language (e.g., de), where the service supports it")
+	flag.StringVar(&searchFlags.region, "region", "", "Results for this country (e.g., DE), using the service's local site where it has one")
+	flag.StringVar(&searchFlags.safe, "safe", "", "SafeSearch level: strict, moderate or off")
+	// --page already opens the landing page, so paging through results uses --results-page
+	flag.IntVar(&searchFlags.page, "results-page", 0, "Open this page of results (1 is the first)")
+	flag.IntVar(&searchFlags.num, "num", 0, "Results per page, where the service supports it")
+	flag.StringVar(&searchFlags.sort, "sort", "", "Shopping sort order: price-asc, price-desc, newest or relevance")
+	flag.Float64Var(&searchFlags.minPrice, "min-price", 0, "Shopping: lowest price")
+	flag.Float64Var(&searchFlags.maxPrice, "max-price", 0, "Shopping: highest price")
+	flag.StringVar(&searchFlags.condition, "condition", "", "Shopping: new, used or refurbished")
+	showQueries := flag.Bool("show-queries", false, "Print the query each service receives after its query_transform rules")
+	verbose := flag.Bool("v", false, "Report how long each launch took")
+	verboseLong := flag.Bool("verbose", false, "Report how long each launch took")
+	var openFlags hunt.OpenSettings
+	flag.StringVar(&openFlags.Strategy, "strategy", "", "Tab-opening strategy: sequential, staggered-parallel or adaptive")
+	flag.StringVar(&openFlags.Delay, "delay", "", "Delay between launches (e.g., 300ms)")
+	flag.IntVar(&openFlags.Concurrency, "concurrency", 0, "Maximum simultaneous launches for staggered-parallel")
+	flag.StringVar(&openFlags.Timeout, "timeout", "", "Maximum wait for each opener to exit before detaching from it")
+	flag.Parse()
+
+	// Combine short and long flags
+	*interactive = *interactive || *interactiveLong
+	*servicesFlag = *servicesFlag || *servicesFlagLong
+	*verbose = *verbose || *verboseLong
+
+	if *vertical != "" {
+		canonical, err := hunt.ParseVertical(*vertical)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		*vertical = canonical
+	}
+
+	// Check for test mode
+	testMode := os.Getenv("HUNT_TEST_MODE") != ""
+
+	// Load configuration
+	config, err := hunt.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+
+	// Resolve tab-opening options: defaults, then config settings, then flags
+	openOpts, err := config.Settings.Open.Apply(hunt.DefaultOpenOptions())
+	if err == nil {
+		openOpts, err = openFlags.Apply(openOpts)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	openOpts.Verbose = *verbose
+	openOpts.TestMode = testMode
+
+	// Search options: flags, falling back to config settings
+	searchOpts, err := searchFlags.options(config.Settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Get engines for the selected category
+	engines := config.GetEnginesByCategory(category)
+	if len(engines) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No services found for category '%s'\n", category)
+		os.Exit(exitConfig)
+	}
+
+	// Parse arguments manually to handle -s flag with multiple selections
+	args := flag.Args()
+	var serviceSelections []string
+	var searchTermParts []string
+
+	if *servicesFlag {
+		// In services mode, collect service selections until we hit something that doesn't look like a service
+		for i := 0; i < len(args); i++ {
+			arg := args[i]
+
+			if arg == "--" {
+				// Explicit separator - everything after is search term
+				searchTermParts = args[i+1:]
+				break
+			}
+
+			// Check if this looks like a service selection
+			if isServiceSelection(arg, engines) {
+				serviceSelections = append(serviceSelections, arg)
+			} else {
+				// This doesn't look like a service, so it's the start of the search term
+				searchTermParts = args[i:]
+				break
+			}
+		}
+	} else {
+		// Not in services mode, all remaining args are the search term
+		searchTermParts = args
+	}
+
+	// Join search term parts
+	searchTerm := strings.Join(searchTermParts, " ")
+
+	// Validate search term
+	if searchTerm == "" {
+		printUsage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+
+	// Validate flags
+	if *interactive && *servicesFlag {
+		fmt.Fprintf(os.Stderr, "Error: Cannot use both -i/--interactive and -s/--services flags together.\n")
+		os.Exit(exitUsage)
+	}
+	if *remoteFlag && *pageFlag {
+		fmt.Fprintf(os.Stderr, "Error: Cannot use --page with --remote: the landing page needs a local browser.\n")
+		os.Exit(exitUsage)
+	}
+
+	// Bangs (e.g., "!youtube") select engines from any category and are removed from the search term
+	var bangs []hunt.Bang
+	if !*noBangs {
+		var unknownBangs []string
+		bangs, searchTerm, unknownBangs = hunt.ParseBangs(config, searchTerm)
+		for _, token := range unknownBangs {
+			fmt.Fprintf(os.Stderr, "Warning: Unknown bang '%s', keeping it in the search term\n", token)
+		}
+		if len(bangs) > 0 && (*interactive || *servicesFlag) {
+			fmt.Fprintf(os.Stderr, "Error: Cannot combine bangs with -i/--interactive or -s/--services (use --no-bangs to search for %s literally).\n", bangs[0].Token)
+			os.Exit(exitUsage)
+		}
+		if strings.TrimSpace(searchTerm) == "" {
+			fmt.Fprintf(os.Stderr, "Error: No search term left after removing bangs.\n")
+			os.Exit(exitUsage)
+		}
+	}
+
+	// Determine which engines to use
+	var selected []hunt.SelectedEngine
+	var selectedIndices []int
+
+	if len(bangs) > 0 {
+		fmt.Println("Selected services:")
+		for _, bang := range bangs {
+			selected = append(selected, bang.Engine)
+			fmt.Printf("  - %s (%s)\n", bang.Engine.Engine.Name, bang.Token)
+		}
+		fmt.Println()
+	} else if *interactive {
+		// If category was explicitly set via subcommand, pass it to interactive mode
+		// Otherwise, let user choose category (pass empty string)
+		categoryForInteractive := ""
+		if categoryExplicitlySet {
+			categoryForInteractive = category
+		}
+		selectedCategory, selectedEngines, err := handleInteractiveMode(config, categoryForInteractive)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		// The category may have been chosen interactively, so look indices up in its engines
+		category = selectedCategory
+		engines = config.GetEnginesByCategory(category)
+		// Convert selected engines to indices for the engines array
+		selectedIndices = make([]int, len(selectedEngines))
+		for i, selectedEngine := range selectedEngines {
+			// Find the index of this engine in the engines array
+			for j, engine := range engines {
+				if engine.Name == selectedEngine.Name && engine.URL == selectedEngine.URL {
+					selectedIndices[i] = j
+					break
+				}
+			}
+		}
+	} else if *servicesFlag {
+		if len(serviceSelections) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: -s/--services flag requires at least one service selection.\n")
+			fmt.Fprintf(os.Stderr, "Usage: %s -s 1 3 5 'search term'\n", os.Args[0])
+			os.Exit(exitUsage)
+		}
+
+		selectedIndices, err = hunt.ParseSelections(os.Stderr, serviceSelections, engines)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		fmt.Println("Selected services:")
+		for _, idx := range selectedIndices {
+			fmt.Printf("  - %s\n", engines[idx].Name)
+		}
+		fmt.Println()
+	} else {
+		// Default: select all engines in the category
+		selectedIndices = make([]int, len(engines))
+		for i := range engines {
+			selectedIndices[i] = i
+		}
+	}
+
+	for _, idx := range selectedIndices {
+		selected = append(selected, hunt.SelectedEngine{Category: category, Engine: engines[idx]})
+	}
+
+	if *vertical != "" {
+		var missing []string
+		selected, missing = hunt.SelectVertical(selected, *vertical, *verticalFallback)
+		printVerticalReport(os.Stdout, *vertical, missing, *verticalFallback)
+		if len(selected) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: None of the selected services has a %s vertical (use --vertical-fallback to search them anyway).\n", *vertical)
+			os.Exit(exitUsage)
+		}
+	}
+
+	selected, unsupported, err := hunt.ApplySearchOptions(selected, searchOpts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	if *showQueries {
+		printQueries(os.Stdout, selected, searchTerm)
+	}
+	printDropped(os.Stdout, selected, searchTerm)
+
+	// Build URLs
+	remote := *remoteFlag || hunt.IsRemoteSession(os.Getenv, runtime.GOOS)
+	targets, err := buildTargets(selected, searchTerm, *privateFlag, remote)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitOpenFailed)
+	}
+	needsPrivate := false
+	for _, target := range targets {
+		needsPrivate = needsPrivate || target.Private
+	}
+
+	// Without a usable local display (e.g., over SSH), print links instead of opening a browser
+	if remote {
+		if *pageFlag {
+			fmt.Fprintf(os.Stderr, "Warning: --page needs a local browser, ignoring it and printing links instead.\n")
+		}
+		fmt.Println("No local display, printing links instead of opening a browser:")
+		hunt.WriteLinks(os.Stdout, targets, isTerminal(os.Stdout))
+		if *copyFlag {
+			copyURLs(targets)
+		}
+
+		fmt.Println()
+		fmt.Printf("Links for: %s\n", searchTerm)
+		fmt.Printf("Total services used: %d\n", len(selected))
+		printUnsupportedOptions(os.Stdout, unsupported)
+		os.Exit(exitOK)
+	}
+
+	// Replace the individual tabs with a single landing page that links to all of them
+	if *pageFlag {
+		links := make([]hunt.PageLink, len(selected))
+		for i, s := range selected {
+			// Form pages are already filled in, so the page can't retarget them for an edited query
+			isForm := hunt.BuildSearchRequest(s.Engine, searchTerm).Method == hunt.MethodPost
+			links[i] = hunt.PageLink{Category: s.Category, Engine: s.Engine, URL: targets[i].URL, Fixed: isForm}
+		}
+		pagePath, err := hunt.WriteLandingPageFile(searchTerm, links)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitOpenFailed)
+		}
+		fmt.Printf("Landing page: %s\n", pagePath)
+		// Private engines make the whole page private, since links open in the page's window
+		targets = []hunt.OpenTarget{{Name: "landing page", URL: pagePath, Private: needsPrivate}}
+	}
+
+	// Locate a private-capable browser up front so we never fall back to a normal tab
+	var privateBrowser *hunt.PrivateBrowser
+	if needsPrivate {
+		privateBrowser, err = hunt.FindPrivateBrowser()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot open %s in a private window: %v\n", privateTargetNames(targets), err)
+			os.Exit(exitOpenFailed)
+		}
+	}
+
+	// Open URLs, stopping early (and reporting what was skipped) on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	opener := &hunt.Opener{Options: openOpts, Browser: privateBrowser, Out: os.Stdout, Err: os.Stderr}
+	openErr := opener.Open(ctx, targets)
+	stop()
+	if *copyFlag {
+		copyURLs(targets)
+	}
+
+	// Summary
+	fmt.Println()
+	fmt.Printf("Opened searches for: %s\n", searchTerm)
+	fmt.Printf("Total services used: %d\n", len(selected))
+	code := printOpenSummary(os.Stdout, os.Stderr, len(targets), openErr)
+	printUnsupportedOptions(os.Stdout, unsupported)
+	os.Exit(code)
+}
+
+// searchOptionFlags holds the search option flags as typed
+type searchOptionFlags struct {
+	since, until, past string
+	lang, region       string
+	safe               string
+	page, num          int
+	sort, condition    string
+	minPrice, maxPrice float64
+}
+
+// options parses the flags into search options
+// --lang, --region and --safe default to the config settings.
+func (f searchOptionFlags) options(settings hunt.Settings) (hunt.SearchOptions, error) {
+	if f.lang == "" {
+		f.lang = settings.Locale.Lang
+	}
+	if f.region == "" {
+		f.region = settings.Locale.Region
+	}
+	if f.safe == "" {
+		f.safe = settings.SafeSearch
+	}
+
+	opts := hunt.SearchOptions{Page: f.page, Num: f.num, MinPrice: f.minPrice, MaxPrice: f.maxPrice}
+	var err error
+	if f.since != "" {
+		if opts.Since, err = time.Parse(hunt.DateLayout, f.since); err != nil {
+			return opts, fmt.Errorf("invalid --since date %q (want YYYY-MM-DD)", f.since)
+		}
+	}
+	if f.until != "" {
+		if opts.Until, err = time.Parse(hunt.DateLayout, f.until); err != nil {
+			return opts, fmt.Errorf("invalid --until date %q (want YYYY-MM-DD)", f.until)
+		}
+	}
+	if f.past != "" {
+		if opts.Past, err = hunt.ParsePeriod(f.past); err != nil {
+			return opts, err
+		}
+	}
+	if f.lang != "" {
+		if opts.Lang, err = hunt.ParseLang(f.lang); err != nil {
+			return opts, err
+		}
+	}
+	if f.region != "" {
+		if opts.Region, err = hunt.ParseRegion(f.region); err != nil {
+			return opts, err
+		}
+	}
+	if f.safe != "" {
+		if opts.SafeSearch, err = hunt.ParseSafeSearch(f.safe); err != nil {
+			return opts, err
+		}
+	}
+	if f.sort != "" {
+		if opts.Sort, err = hunt.ParseSortOrder(f.sort); err != nil {
+			return opts, err
+		}
+	}
+	if f.condition != "" {
+		if opts.Condition, err = hunt.ParseCondition(f.condition); err != nil {
+			return opts, err
+		}
+	}
+	return opts, opts.Validate()
+}
+
+// printUnsupportedOptions lists the services that searched without some of the options
+func printUnsupportedOptions(w io.Writer, unsupported []hunt.UnsupportedOptions) {
+	if len(unsupported) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Unfiltered (option not supported by the service):")
+	for _, u := range unsupported {
+		fmt.Fprintf(w, "  - %s: %s\n", u.Engine, strings.Join(u.Options, ", "))
+	}
+}
+
+// printQueries prints the query each selected engine receives after its query_transform steps
+func printQueries(w io.Writer, selected []hunt.SelectedEngine, searchTerm string) {
+	fmt.Fprintln(w, "Queries sent:")
+	for _, s := range selected {
+		fmt.Fprintf(w, "  %s: %s\n", s.Engine.Name, s.Engine.TransformQuery(searchTerm))
+	}
+	fmt.Fprintln(w)
+}
+
+// printDropped lists the services that can't use part of the search term, such as site:
+// on a shopping site, and what each of them drops
+func printDropped(w io.Writer, selected []hunt.SelectedEngine, searchTerm string) {
+	printed := false
+	for _, s := range selected {
+		dropped := s.Engine.PrepareQuery(searchTerm).Dropped
+		if len(dropped) == 0 {
+			continue
+		}
+		if !printed {
+			fmt.Fprintln(w, "Not supported, dropped from the query:")
+			printed = true
+		}
+		fmt.Fprintf(w, "  %s: %s\n", s.Engine.Name, strings.Join(dropped, ", "))
+	}
+	if printed {
+		fmt.Fprintln(w)
+	}
+}
+
+// printVerticalReport lists the services that have no page for the vertical
+func printVerticalReport(w io.Writer, vertical string, missing []string, fallback bool) {
+	if len(missing) == 0 {
+		return
+	}
+	if fallback {
+		fmt.Fprintf(w, "No %s vertical, using the main search (%d):\n", vertical, len(missing))
+	} else {
+		fmt.Fprintf(w, "No %s vertical, skipped (%d):\n", vertical, len(missing))
+	}
+	for _, name := range missing {
+		fmt.Fprintf(w, "  - %s\n", name)
+	}
+	fmt.Fprintln(w)
+}
+
+// buildTargets turns the selected engines into targets to open
+// POST searches (and GET URLs over an engine's max_url_length) open a temporary page that
+// submits the form. A remote session can't use such a page, so it gets the form action.
+func buildTargets(selected []hunt.SelectedEngine, searchTerm string, private, remote bool) ([]hunt.OpenTarget, error) {
+	targets := make([]hunt.OpenTarget, len(selected))
+	for i, s := range selected {
+		req := hunt.BuildSearchRequest(s.Engine, searchTerm)
+		targets[i] = hunt.OpenTarget{
+			Name:    s.Engine.Name,
+			URL:     req.URL,
+			Private: private || s.Engine.Private,
+		}
+		if req.Method != hunt.MethodPost {
+			continue
+		}
+		if remote {
+			fmt.Fprintf(os.Stderr, "Warning: %s needs a POST form, which can't be shared as a link; printing the form's address instead\n", s.Engine.Name)
+			continue
+		}
+		path, err := hunt.WriteFormPageFile(s.Engine.Name, req)
+		if err != nil {
+			return nil, err
+		}
+		targets[i].URL = path
+	}
+	return targets, nil
+}
+
+// printOpenSummary reports how many targets opened and which failed, returning the exit code
+// Counts go to w; failure details go to errW
+func printOpenSummary(w, errW io.Writer, total int, openErr error) int {
+	var failures *hunt.OpenError
+	if !errors.As(openErr, &failures) {
+		if openErr != nil {
+			fmt.Fprintf(errW, "Error opening URLs: %v\n", openErr)
+			return exitOpenFailed
+		}
+		fmt.Fprintf(w, "Opened: %d, Failed: 0\n", total)
+		return exitOK
+	}
+
+	if failures.Interrupted() {
+		fmt.Fprintf(w, "Opened: %d, Failed: %d, Skipped: %d\n", failures.Opened, len(failures.Failures), len(failures.Skipped))
+	} else {
+		fmt.Fprintf(w, "Opened: %d, Failed: %d\n", failures.Opened, len(failures.Failures))
+	}
+	if len(failures.Failures) > 0 {
+		fmt.Fprintf(errW, "Failed to open:\n")
+		for _, failure := range failures.Failures {
+			fmt.Fprintf(errW, "  - %s: %v\n", failure.Name, failure.Err)
+		}
+	}
+	if failures.Interrupted() {
+		fmt.Fprintf(errW, "Interrupted, skipped:\n")
+		for _, name := range failures.Skipped {
+			fmt.Fprintf(errW, "  - %s\n", name)
+		}
+		return exitInterrupted
+	}
+
+	if failures.Total() {
+		return exitOpenFailed
+	}
+	return exitPartialFailure
+}
+
+// mapSubcommandToCategory maps a subcommand string to a category
+// Returns empty string if subcommand is not recognized
+func mapSubcommandToCategory(subcommand string) string {
+	switch subcommand {
+	case "shop", "shopping":
+		return "shop"
+	case "search":
+		return "search"
+	case "technews", "tech-news", "tech":
+		return "technews"
+	case "news":
+		return "news"
+	default:
+		return ""
+	}
+}
+
+// copyURLs copies every target URL, one per line, to the local clipboard through the terminal
+func copyURLs(targets []hunt.OpenTarget) {
+	if !isTerminal(os.Stdout) {
+		fmt.Fprintf(os.Stderr, "Warning: --copy needs a terminal on stdout, clipboard not updated\n")
+		return
+	}
+
+	urls := make([]string, len(targets))
+	for i, target := range targets {
+		urls[i] = target.URL
+	}
+	if err := hunt.WriteClipboard(os.Stdout, strings.Join(urls, "\n"), os.Getenv("TMUX") != ""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to copy URLs: %v\n", err)
+		return
+	}
+	fmt.Printf("Copied %d URLs to the clipboard\n", len(urls))
+}
+
+// privateTargetNames describes which targets need a private window, for error messages
+func privateTargetNames(targets []hunt.OpenTarget) string {
+	var names []string
+	for _, target := range targets {
+		if target.Private {
+			names = append(names, target.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// isServiceSelection checks if an argument looks like a service selection
+func isServiceSelection(arg string, engines []hunt.SearchEngine) bool {
+	argLower := strings.ToLower(strings.TrimSpace(arg))
+
+	// Check for "all"
+	if argLower == "all" {
+		return true
+	}
+
+	// Check if it's a number (0-N where N is the number of engines)
+	if num, err := strconv.Atoi(arg); err == nil {
+		return num >= 0 && num <= len(engines)
+	}
+
+	// Check if it matches an engine name (case-insensitive)
+	for _, engine := range engines {
+		if strings.EqualFold(engine.Name, arg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleInteractiveMode displays category selection first (if not pre-selected), then service selection
+// Returns the chosen category along with the selected engines
+func handleInteractiveMode(config *hunt.Config, preSelectedCategory string) (string, []hunt.SearchEngine, error) {
+	var selectedCategory string
+	var engines []hunt.SearchEngine
+	reader := bufio.NewReader(os.Stdin)
+
+	// Step 1: Category selection (skip if category was pre-selected via subcommand)
+	if preSelectedCategory == "" {
+		// Show category selection
+		categories := make([]string, 0, len(config.Categories))
+		for cat := range config.Categories {
+			categories = append(categories, cat)
+		}
+
+		// Sort categories for consistent display (search first, then alphabetical)
+		sortedCategories := make([]string, 0, len(categories))
+		if _, ok := config.Categories["search"]; ok {
+			sortedCategories = append(sortedCategories, "search")
+		}
+		for _, cat := range categories {
+			if cat != "search" {
+				sortedCategories = append(sortedCategories, cat)
+			}
+		}
+
+		fmt.Println("Select category:")
+		fmt.Println()
+		for i, cat := range sortedCategories {
+			displayName := hunt.FormatCategoryName(cat)
+			fmt.Printf("  %d) %s\n", i+1, displayName)
+		}
+
+		fmt.Println()
+		fmt.Print("Enter category number: ")
+
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read input: %w", err)
+		}
+
+		input = strings.TrimSpace(input)
+		categoryNum, err := strconv.Atoi(input)
+		if err != nil || categoryNum < 1 || categoryNum > len(sortedCategories) {
+			return "", nil, fmt.Errorf("invalid category selection: %q", input)
+		}
+
+		selectedCategory = sortedCategories[categoryNum-1]
+	} else {
+		// Category was pre-selected via subcommand
+		selectedCategory = preSelectedCategory
+	}
+
+	engines = config.GetEnginesByCategory(selectedCategory)
+	if len(engines) == 0 {
+		return "", nil, fmt.Errorf("no services found for category %q", selectedCategory)
+	}
+
+	fmt.Println()
+
+	// Step 2: Service selection
+	fmt.Println("Select services to use (enter numbers, separated by spaces):")
+	fmt.Println()
+
+	// Display "all" option
+	fmt.Println("  0) All services")
+
+	// Display engines (1-indexed for user)
+	for i, engine := range engines {
+		fmt.Printf("  %d) %s\n", i+1, engine.Name)
+	}
+
+	fmt.Println()
+	fmt.Print("Enter selection(s): ")
+
+	// Read user input
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	// Parse input (split by spaces)
+	input = strings.TrimSpace(input)
+	selections := strings.Fields(input)
+
+	indices, err := hunt.ParseSelections(os.Stderr, selections, engines)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Convert indices to engines
+	selectedEngines := make([]hunt.SearchEngine, len(indices))
+	for i, idx := range indices {
+		selectedEngines[i] = engines[idx]
+	}
+
+	fmt.Println()
+	fmt.Println("Selected services:")
+	for _, engine := range selectedEngines {
+		fmt.Printf("  - %s\n", engine.Name)
+	}
+	fmt.Println()
+
+	return selectedCategory, selectedEngines, nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [SUBCOMMAND] [-i|--interactive] [-s|--services SELECTION ...] [--vertical NAME] [--past PERIOD | --since DATE --until DATE] [--lang CODE] [--region CODE] [--safe LEVEL] [--results-page N] [--num N] [SHOPPING OPTIONS] [--private] [--page] [--remote] [--copy] [OPEN OPTIONS] <search term>\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Subcommands:\n")
+	fmt.Fprintf(w, "  (none)                   Search across search engines (default)\n")
+	fmt.Fprintf(w, "  shop                     Search across shopping sites\n")
+	fmt.Fprintf(w, "  technews                 Search across tech news sites\n")
+	fmt.Fprintf(w, "  news                     Search across news sites\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Commands (run with --help for details):\n")
+	fmt.Fprintf(w, "  serve [--addr HOST:PORT] Serve a search page and OpenSearch descriptor for your browser\n")
+	fmt.Fprintf(w, "  redirect [--addr HOST:PORT] [--default ENGINE]\n")
+	fmt.Fprintf(w, "                           Redirect /go?q=youtube cats to one engine's results\n")
+	fmt.Fprintf(w, "  api [--addr HOST:PORT]   Serve the engine catalog and URL building as a JSON API\n")
+	fmt.Fprintf(w, "  import bangs FILE        Add engines from a DuckDuckGo bang.js file\n")
+	fmt.Fprintf(w, "  import opensearch FILE   Add an engine from an OpenSearch description (.xml)\n")
+	fmt.Fprintf(w, "  import firefox PROFILE   Add custom search engines from a Firefox profile\n")
+	fmt.Fprintf(w, "  import chrome FILE       Add keyword searches exported from Chrome\n")
+	fmt.Fprintf(w, "  export opensearch NAME   Write OpenSearch descriptions for an engine or category\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Examples:\n")
+	fmt.Fprintf(w, "  %s 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s shop 'laptop'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s technews 'AI'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s news 'election'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s -i 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s shop -i 'laptop'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s shop --sort price-asc --max-price 300 --condition used 'iphone 15'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s -s 1 3 5 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s shop -s 1 3 'laptop'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s -s Bing Google Mojeek 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s '!youtube !hackernews rust async'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --vertical images 'aurora'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s technews --past week 'rust async'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s shop --region DE --lang de 'kaffeemühle'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --safe strict 'science fair ideas'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --results-page 2 --num 50 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --private 'gift ideas'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --page 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --remote --copy 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "  %s --strategy adaptive --timeout 2s -v 'machine learning'\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Options:\n")
+	fmt.Fprintf(w, "  -h, --help                Show this help message and exit\n")
+	fmt.Fprintf(w, "  -i, --interactive         Interactive mode to select services\n")
+	fmt.Fprintf(w, "  -s, --services SELECTION  Specify services by number (0 for all, 1-N for services) or name\n")
+	fmt.Fprintf(w, "      --no-bangs            Don't treat !name tokens in the search term as engine selections\n")
+	fmt.Fprintf(w, "      --vertical NAME       Search the images, videos, news, maps or scholar pages of services\n")
+	fmt.Fprintf(w, "                            that declare them; other services are skipped and listed\n")
+	fmt.Fprintf(w, "      --vertical-fallback   With --vertical, search services without the vertical on their main page\n")
+	fmt.Fprintf(w, "      --past PERIOD         Only results from the past hour, day, week, month or year\n")
+	fmt.Fprintf(w, "      --since DATE          Only results from DATE (YYYY-MM-DD) on\n")
+	fmt.Fprintf(w, "      --until DATE          Only results up to and including DATE (YYYY-MM-DD)\n")
+	fmt.Fprintf(w, "      --lang CODE           Results in this language (e.g., de); default from settings.locale\n")
+	fmt.Fprintf(w, "      --region CODE         Results for this country (e.g., DE), on the service's local site if it\n")
+	fmt.Fprintf(w, "                            has one; fails if a service lists its regions but not this one\n")
+	fmt.Fprintf(w, "      --safe LEVEL          SafeSearch: strict, moderate or off; default from settings.safe_search\n")
+	fmt.Fprintf(w, "      --results-page N      Open page N of each service's results (--page is the landing page)\n")
+	fmt.Fprintf(w, "      --num N               Results per page, where the service supports it\n")
+	fmt.Fprintf(w, "      --show-queries        Print the query each service receives after its query_transform rules\n")
+	fmt.Fprintf(w, "      --private             Open in a private window (Firefox --private-window, Chromium --incognito)\n")
+	fmt.Fprintf(w, "      --page                Open one offline landing page linking every service (with an\n")
+	fmt.Fprintf(w, "                            editable query and open-all buttons) instead of one tab each\n")
+	fmt.Fprintf(w, "      --remote              Print clickable (OSC 8) links instead of opening a browser;\n")
+	fmt.Fprintf(w, "                            automatic when there is no DISPLAY/WAYLAND_DISPLAY or over SSH\n")
+	fmt.Fprintf(w, "      --copy                Also copy the URLs to your local clipboard (OSC 52)\n")
+	fmt.Fprintf(w, "  -v, --verbose             Report how long each browser launch took\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Shopping options (for the shop subcommand; each site maps them onto its own filters):\n")
+	fmt.Fprintf(w, "      --sort ORDER          price-asc, price-desc, newest or relevance\n")
+	fmt.Fprintf(w, "      --min-price PRICE     Lowest price\n")
+	fmt.Fprintf(w, "      --max-price PRICE     Highest price\n")
+	fmt.Fprintf(w, "      --condition NAME      new, used or refurbished\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Open options (override the \"open\" block in search_engines.json settings):\n")
+	fmt.Fprintf(w, "      --strategy NAME       sequential (default), staggered-parallel, or adaptive\n")
+	fmt.Fprintf(w, "      --delay DURATION      Delay between launches (default 300ms)\n")
+	fmt.Fprintf(w, "      --concurrency N       Maximum simultaneous launches for staggered-parallel (default 4)\n")
+	fmt.Fprintf(w, "      --timeout DURATION    Maximum wait for each opener to exit before detaching from it (default 3s)\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Exit status:\n")
+	fmt.Fprintf(w, "  %d  All selected services opened\n", exitOK)
+	fmt.Fprintf(w, "  %d  No service could be opened\n", exitOpenFailed)
+	fmt.Fprintf(w, "  %d  Usage error (invalid arguments, flags or selections)\n", exitUsage)
+	fmt.Fprintf(w, "  %d  Configuration error (search_engines.json missing or invalid)\n", exitConfig)
+	fmt.Fprintf(w, "  %d  Partial failure (some services opened, others failed)\n", exitPartialFailure)
+	fmt.Fprintf(w, "  %d  A command such as serve, import or export failed (e.g., address in use)\n", exitFailure)
+	fmt.Fprintf(w, "  %d  Interrupted (Ctrl-C); remaining services were skipped\n", exitInterrupted)
+}
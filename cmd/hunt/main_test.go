@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aneely/hunt"
+)
+
+func TestMapSubcommandToCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		subcommand string
+		want       string
+	}{
+		{
+			name:       "shop subcommand",
+			subcommand: "shop",
+			want:       "shop",
+		},
+		{
+			name:       "shopping subcommand",
+			subcommand: "shopping",
+			want:       "shop",
+		},
+		{
+			name:       "search subcommand",
+			subcommand: "search",
+			want:       "search",
+		},
+		{
+			name:       "technews subcommand",
+			subcommand: "technews",
+			want:       "technews",
+		},
+		{
+			name:       "tech-news subcommand",
+			subcommand: "tech-news",
+			want:       "technews",
+		},
+		{
+			name:       "tech subcommand",
+			subcommand: "tech",
+			want:       "technews",
+		},
+		{
+			name:       "news subcommand",
+			subcommand: "news",
+			want:       "news",
+		},
+		{
+			name:       "unknown subcommand",
+			subcommand: "unknown",
+			want:       "",
+		},
+		{
+			name:       "empty subcommand",
+			subcommand: "",
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapSubcommandToCategory(tt.subcommand)
+			if got != tt.want {
+				t.Errorf("mapSubcommandToCategory(%q) = %q, want %q", tt.subcommand, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintUsage(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantContains []string
+	}{
+		{
+			name: "contains usage information",
+			wantContains: []string{
+				"Usage:",
+				"Subcommands:",
+				"shop",
+				"technews",
+				"news",
+				"Examples:",
+				"Options:",
+				"-h, --help",
+				"-i, --interactive",
+				"-s, --services",
+				"--private",
+				"--page",
+				"--remote",
+				"--copy",
+				"--vertical",
+				"--past",
+				"--since",
+				"--lang",
+				"--region",
+				"--safe",
+				"--results-page",
+				"--num",
+				"--sort",
+				"--min-price",
+				"--condition",
+				"-v, --verbose",
+				"--strategy",
+				"--delay",
+				"--concurrency",
+				"Exit status:",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Capture output using a buffer
+			var buf bytes.Buffer
+			printUsage(&buf)
+			output := buf.String()
+
+			// Check that all expected strings are present
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("printUsage() output missing %q", want)
+				}
+			}
+
+			// Verify output is not empty
+			if len(output) == 0 {
+				t.Error("printUsage() produced empty output")
+			}
+		})
+	}
+}
+
+func TestPrintOpenSummary(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		openErr      error
+		wantCode     int
+		wantStdout   string
+		wantInStderr []string
+	}{
+		{
+			name:       "all opened",
+			total:      3,
+			openErr:    nil,
+			wantCode:   exitOK,
+			wantStdout: "Opened: 3, Failed: 0\n",
+		},
+		{
+			name:  "partial failure",
+			total: 3,
+			openErr: &hunt.OpenError{
+				Opened:   2,
+				Failures: []hunt.OpenFailure{{Name: "Bing", Err: errors.New("exit status 3")}},
+			},
+			wantCode:     exitPartialFailure,
+			wantStdout:   "Opened: 2, Failed: 1\n",
+			wantInStderr: []string{"Failed to open:", "Bing: exit status 3"},
+		},
+		{
+			name:  "total failure",
+			total: 2,
+			openErr: &hunt.OpenError{
+				Failures: []hunt.OpenFailure{
+					{Name: "Bing", Err: errors.New("exit status 3")},
+					{Name: "Google", Err: errors.New("exit status 4")},
+				},
+			},
+			wantCode:     exitOpenFailed,
+			wantStdout:   "Opened: 0, Failed: 2\n",
+			wantInStderr: []string{"Bing: exit status 3", "Google: exit status 4"},
+		},
+		{
+			name:  "interrupted",
+			total: 3,
+			openErr: &hunt.OpenError{
+				Opened:  1,
+				Skipped: []string{"Google", "Yahoo"},
+				Cause:   errors.New("context canceled"),
+			},
+			wantCode:     exitInterrupted,
+			wantStdout:   "Opened: 1, Failed: 0, Skipped: 2\n",
+			wantInStderr: []string{"Interrupted, skipped:", "- Google", "- Yahoo"},
+		},
+		{
+			name:         "unexpected error",
+			total:        1,
+			openErr:      errors.New("boom"),
+			wantCode:     exitOpenFailed,
+			wantInStderr: []string{"boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			got := printOpenSummary(&stdout, &stderr, tt.total, tt.openErr)
+			if got != tt.wantCode {
+				t.Errorf("printOpenSummary() = %d, want %d", got, tt.wantCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("printOpenSummary() stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			for _, want := range tt.wantInStderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("printOpenSummary() stderr missing %q\nGot: %s", want, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestBuildTargets(t *testing.T) {
+	selected := []hunt.SelectedEngine{
+		{Category: "search", Engine: hunt.SearchEngine{Name: "Bing", URL: "https://www.bing.com/search?q="}},
+		{Category: "books", Engine: hunt.SearchEngine{
+			Name:   "Catalog",
+			URL:    "https://catalog.example.edu/search",
+			Method: hunt.MethodPost,
+			Form:   []hunt.FormField{{Name: "terms", Value: "{query}"}},
+		}},
+	}
+
+	targets, err := buildTargets(selected, "dune", false, false)
+	if err != nil {
+		t.Fatalf("buildTargets() error = %v", err)
+	}
+	if targets[0].URL != "https://www.bing.com/search?q=dune" {
+		t.Errorf("GET target URL = %q", targets[0].URL)
+	}
+	page, err := os.ReadFile(targets[1].URL)
+	if err != nil {
+		t.Fatalf("POST target is not a form page: %v", err)
+	}
+	os.Remove(targets[1].URL)
+	if !strings.Contains(string(page), `value="dune"`) {
+		t.Errorf("form page does not carry the query:\n%s", page)
+	}
+
+	// Remote sessions can't open a local form page, so they get the form action
+	targets, err = buildTargets(selected, "dune", false, true)
+	if err != nil {
+		t.Fatalf("buildTargets() error = %v", err)
+	}
+	if targets[1].URL != "https://catalog.example.edu/search" {
+		t.Errorf("remote POST target URL = %q, want the form action", targets[1].URL)
+	}
+}
+
+func TestPrintQueries(t *testing.T) {
+	selected := []hunt.SelectedEngine{
+		{Category: "search", Engine: hunt.SearchEngine{Name: "Bing"}},
+		{Category: "search", Engine: hunt.SearchEngine{Name: "Reddit via Google", QueryTransform: []hunt.QueryTransform{
+			{Op: hunt.TransformPrefix, Value: "site:reddit.com "},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	printQueries(&buf, selected, "mechanical keyboards")
+	want := "Queries sent:\n  Bing: mechanical keyboards\n  Reddit via Google: site:reddit.com mechanical keyboards\n\n"
+	if buf.String() != want {
+		t.Errorf("printQueries() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintVerticalReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		missing  []string
+		fallback bool
+		want     string
+	}{
+		{"nothing missing", nil, false, ""},
+		{"skipped", []string{"Kagi", "Mojeek"}, false, "No images vertical, skipped (2):\n  - Kagi\n  - Mojeek\n\n"},
+		{"fallback", []string{"Mojeek"}, true, "No images vertical, using the main search (1):\n  - Mojeek\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printVerticalReport(&buf, "images", tt.missing, tt.fallback)
+			if buf.String() != tt.want {
+				t.Errorf("printVerticalReport() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintDropped(t *testing.T) {
+	selected := []hunt.SelectedEngine{
+		{Category: "search", Engine: hunt.SearchEngine{Name: "Google"}},
+		{Category: "shop", Engine: hunt.SearchEngine{Name: "Amazon", Operators: hunt.OperatorDialect{Syntax: hunt.SyntaxStrip}}},
+	}
+
+	var buf bytes.Buffer
+	printDropped(&buf, selected, "usb hub site:reddit.com -cheap")
+	want := "Not supported, dropped from the query:\n  Amazon: site:reddit.com, -cheap\n\n"
+	if buf.String() != want {
+		t.Errorf("printDropped() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	printDropped(&buf, selected, "usb hub")
+	if buf.Len() != 0 {
+		t.Errorf("printDropped() with nothing dropped = %q, want no output", buf.String())
+	}
+}
+
+func TestSearchOptionFlags(t *testing.T) {
+	settings := hunt.Settings{Locale: hunt.LocaleSettings{Lang: "en", Region: "US"}, SafeSearch: hunt.SafeModerate}
+
+	tests := []struct {
+		name     string
+		flags    searchOptionFlags
+		settings hunt.Settings
+		want     hunt.SearchOptions
+		wantErr  bool
+	}{
+		{name: "none"},
+		{name: "since", flags: searchOptionFlags{since: "2024-01-01"}, want: hunt.SearchOptions{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{name: "past is case-insensitive", flags: searchOptionFlags{past: "Week"}, want: hunt.SearchOptions{Past: hunt.PeriodWeek}},
+		{name: "locale is normalized", flags: searchOptionFlags{lang: "DE", region: "gb"}, want: hunt.SearchOptions{Lang: "de", Region: "GB"}},
+		{name: "settings defaults", settings: settings, want: hunt.SearchOptions{Lang: "en", Region: "US", SafeSearch: hunt.SafeModerate}},
+		{name: "flags override settings", flags: searchOptionFlags{region: "DE", safe: "STRICT"}, settings: settings, want: hunt.SearchOptions{Lang: "en", Region: "DE", SafeSearch: hunt.SafeStrict}},
+		{name: "bad date", flags: searchOptionFlags{since: "01/02/2024"}, wantErr: true},
+		{name: "bad period", flags: searchOptionFlags{past: "decade"}, wantErr: true},
+		{name: "past and until", flags: searchOptionFlags{past: "day", until: "2024-01-01"}, wantErr: true},
+		{name: "bad language", flags: searchOptionFlags{lang: "german"}, wantErr: true},
+		{name: "bad region", flags: searchOptionFlags{region: "GBR"}, wantErr: true},
+		{name: "bad safe search level", flags: searchOptionFlags{safe: "on"}, wantErr: true},
+		{name: "paging", flags: searchOptionFlags{page: 3, num: 50}, want: hunt.SearchOptions{Page: 3, Num: 50}},
+		{name: "negative page", flags: searchOptionFlags{page: -1}, wantErr: true},
+		{name: "shopping", flags: searchOptionFlags{sort: "Price-Asc", minPrice: 50, maxPrice: 200, condition: "used"},
+			want: hunt.SearchOptions{Sort: hunt.SortPriceAsc, MinPrice: 50, MaxPrice: 200, Condition: hunt.ConditionUsed}},
+		{name: "bad sort order", flags: searchOptionFlags{sort: "cheapest"}, wantErr: true},
+		{name: "bad condition", flags: searchOptionFlags{condition: "mint"}, wantErr: true},
+		{name: "max below min", flags: searchOptionFlags{minPrice: 200, maxPrice: 50}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.flags.options(tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("options() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("options() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintUnsupportedOptions(t *testing.T) {
+	var buf bytes.Buffer
+	printUnsupportedOptions(&buf, []hunt.UnsupportedOptions{{Engine: "Kagi", Options: []string{hunt.OptionDate}}})
+	want := "Unfiltered (option not supported by the service):\n  - Kagi: date range\n"
+	if buf.String() != want {
+		t.Errorf("printUnsupportedOptions() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aneely/hunt"
+)
+
+// runRedirect implements `hunt redirect`: a keyword redirector in the style of bunnylol
+// Returns the process exit code
+func runRedirect(args []string) int {
+	fs := flag.NewFlagSet("redirect", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "Address to listen on")
+	defaultEngine := fs.String("default", "", "Engine used when the first word names no engine (overrides settings.redirect.default_engine)")
+	fs.Usage = func() { printRedirectUsage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return flagExitCode(err)
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n", fs.Arg(0))
+		return exitUsage
+	}
+
+	configs, code := loadConfigWatcher()
+	if configs == nil {
+		return code
+	}
+	// Fail at startup rather than answer every unmatched query with an error
+	if _, err := redirectDefault(configs.Config(), *defaultEngine); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if *defaultEngine != "" {
+			return exitUsage
+		}
+		return exitConfig
+	}
+	return serveUntilSignal("redirect", *addr, configs, newRedirectHandler(configs, *defaultEngine))
+}
+
+// printRedirectUsage prints help for `hunt redirect`
+func printRedirectUsage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: %s redirect [--addr HOST:PORT] [--default ENGINE]\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Redirects /go?q=QUERY to a single engine. If QUERY starts with an engine name\n")
+	fmt.Fprintf(w, "(case-insensitive, any category, multi-word names included), the rest of the query is\n")
+	fmt.Fprintf(w, "searched there; otherwise the whole query goes to the default engine.\n")
+	fmt.Fprintf(w, "Examples: /go?q=youtube cats, /go?q=hacker news rust\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "The default engine is --default, else settings.redirect.default_engine in\n")
+	fmt.Fprintf(w, "search_engines.json, else the first search engine. /opensearch.xml registers /go.\n")
+	fmt.Fprintf(w, "POST engines get a page that submits their form instead of a redirect.\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Options:\n")
+	fs.PrintDefaults()
+}
+
+// newRedirectHandler returns the handler for `hunt redirect`
+// override, when set, takes precedence over the configured default engine
+func newRedirectHandler(configs *configWatcher, override string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /go", func(w http.ResponseWriter, r *http.Request) {
+		name, req, err := redirectRequest(configs.Config(), r.URL.Query().Get("q"), override)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Method == hunt.MethodPost {
+			writeFormPage(w, name, req)
+			return
+		}
+		http.Redirect(w, r, req.URL, http.StatusFound)
+	})
+	mux.HandleFunc("GET /opensearch.xml", openSearchDescriptorHandler("/go"))
+	return mux
+}
+
+// redirectRequest returns the engine name and search request for a redirect query
+// When the query starts with an engine name, the rest of the query is searched there;
+// otherwise the whole query is searched on the default engine
+func redirectRequest(config *hunt.Config, query, override string) (string, hunt.SearchRequest, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", hunt.SearchRequest{}, fmt.Errorf("missing query: use /go?q=ENGINE QUERY")
+	}
+
+	if engine, rest, ok := matchEngineName(config, query); ok {
+		return engine.Name, hunt.BuildSearchRequest(engine, rest), nil
+	}
+
+	engine, err := redirectDefault(config, override)
+	if err != nil {
+		return "", hunt.SearchRequest{}, err
+	}
+	return engine.Name, hunt.BuildSearchRequest(engine, query), nil
+}
+
+// matchEngineName finds the engine whose name starts query, word by word and case-insensitively
+// The longest name wins, so multi-word names such as "Hacker News" match. Returns the rest of the query.
+func matchEngineName(config *hunt.Config, query string) (hunt.SearchEngine, string, bool) {
+	words := strings.Fields(query)
+	var match hunt.SearchEngine
+	matched := 0
+	for _, category := range config.CategoryNames() {
+		for _, engine := range config.Categories[category] {
+			name := strings.Fields(engine.Name)
+			if len(name) <= matched || len(name) > len(words) {
+				continue
+			}
+			if slices.EqualFunc(name, words[:len(name)], strings.EqualFold) {
+				match, matched = engine, len(name)
+			}
+		}
+	}
+	if matched == 0 {
+		return hunt.SearchEngine{}, "", false
+	}
+	return match, strings.Join(words[matched:], " "), true
+}
+
+// redirectDefault returns the engine for queries without an engine keyword
+// Precedence: override, then settings.redirect.default_engine, then the first search engine
+func redirectDefault(config *hunt.Config, override string) (hunt.SearchEngine, error) {
+	name := override
+	if name == "" {
+		name = config.Settings.Redirect.DefaultEngine
+	}
+	if name != "" {
+		engine, ok := config.FindEngine(name)
+		if !ok {
+			return hunt.SearchEngine{}, fmt.Errorf("default engine %q not found in search_engines.json", name)
+		}
+		return engine, nil
+	}
+
+	if engines := config.GetEnginesByCategory("search"); len(engines) > 0 {
+		return engines[0], nil
+	}
+	return hunt.SearchEngine{}, fmt.Errorf("no default engine: set settings.redirect.default_engine or use --default")
+}
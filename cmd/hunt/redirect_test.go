@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aneely/hunt"
+)
+
+func TestRedirectRequest(t *testing.T) {
+	config := &hunt.Config{Categories: map[string][]hunt.SearchEngine{
+		"search": {
+			{Name: "Bing", URL: "https://www.bing.com/search?q=", Encoding: hunt.Encoding{Delimiter: "+"}},
+			{Name: "Google", URL: "https://www.google.com/search?q=", Encoding: hunt.Encoding{Delimiter: "+"}},
+		},
+		"technews": {
+			{Name: "Hacker", URL: "https://hacker.example/?q=", Encoding: hunt.Encoding{Delimiter: "+"}},
+			{Name: "Hacker News", URL: "https://hn.algolia.com/?q=", Encoding: hunt.Encoding{Delimiter: "%20"}},
+		},
+		"video": {{Name: "YouTube", URL: "https://www.youtube.com/results?search_query=", Encoding: hunt.Encoding{Delimiter: "+"}}},
+	}}
+
+	tests := []struct {
+		name     string
+		query    string
+		override string
+		settings string
+		want     string
+		wantErr  bool
+	}{
+		{"engine keyword", "youtube cats", "", "", "https://www.youtube.com/results?search_query=cats", false},
+		{"keyword is case-insensitive", "GOOGLE go generics", "", "", "https://www.google.com/search?q=go+generics", false},
+		{"keyword only", "google", "", "", "https://www.google.com/search?q=", false},
+		{"multi-word engine name", "hacker news rust async", "", "", "https://hn.algolia.com/?q=rust%20async", false},
+		{"longest engine name wins", "HACKER NEWS", "", "", "https://hn.algolia.com/?q=", false},
+		{"shorter engine name", "hacker newsletter", "", "", "https://hacker.example/?q=newsletter", false},
+		{"no keyword uses first search engine", "cats and dogs", "", "", "https://www.bing.com/search?q=cats+and+dogs", false},
+		{"configured default", "cats", "", "Google", "https://www.google.com/search?q=cats", false},
+		{"override beats configured default", "cats", "youtube", "Google", "https://www.youtube.com/results?search_query=cats", false},
+		{"unknown default", "cats", "altavista", "", "", true},
+		{"empty query", "  ", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Settings.Redirect.DefaultEngine = tt.settings
+			_, got, err := redirectRequest(config, tt.query, tt.override)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("redirectRequest(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if got.URL != tt.want {
+				t.Errorf("redirectRequest(%q) URL = %q, want %q", tt.query, got.URL, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedirectHandler(t *testing.T) {
+	handler := newRedirectHandler(newTestConfigWatcher(t, serveTestConfig), "")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/go?q=swappa+iphone+15", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusFound)
+	}
+	if got, want := rec.Header().Get("Location"), "https://swappa.com/search?q=iphone%2015"; got != want {
+		t.Errorf("Location = %q, want %q", got, want)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/go", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing query status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestRedirectHandler_Post(t *testing.T) {
+	handler := newRedirectHandler(newTestConfigWatcher(t, `{
+  "books": [
+    {"name": "Catalog", "url": "https://catalog.example.edu/search", "method": "POST", "form": [{"name": "terms", "value": "{query}"}]}
+  ]
+}`), "")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/go?q=catalog+dune", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST engine status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `action="https://catalog.example.edu/search"`) || !strings.Contains(body, `value="dune"`) {
+		t.Errorf("POST engine page is missing its form:\n%s", body)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aneely/hunt"
+)
+
+// runServe implements `hunt serve`: a local meta-search endpoint for browsers
+// Returns the process exit code
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "Address to listen on")
+	fs.Usage = func() { printServeUsage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return flagExitCode(err)
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n", fs.Arg(0))
+		return exitUsage
+	}
+
+	return runServer("serve", *addr, newSearchHandler)
+}
+
+// printServeUsage prints help for `hunt serve`
+func printServeUsage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: %s serve [--addr HOST:PORT]\n", os.Args[0])
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Serves a meta-search page and an OpenSearch descriptor so a browser can use hunt\n")
+	fmt.Fprintf(w, "as its search engine. search_engines.json is reloaded whenever it changes.\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Endpoints:\n")
+	fmt.Fprintf(w, "  /search?q=QUERY[&cat=CATEGORY][&s=SERVICE]  Page linking every selected service\n")
+	fmt.Fprintf(w, "  /opensearch.xml[?cat=...&s=...]             OpenSearch descriptor for browser registration\n")
+	fmt.Fprintf(w, "  /form?e=SERVICE&q=QUERY                     Submits a POST service's search form\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "cat and s may be repeated or comma-separated. cat defaults to \"search\"; s defaults to all.\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Options:\n")
+	fs.PrintDefaults()
+}
+
+// flagExitCode maps a FlagSet parse error to an exit code (help is not an error)
+func flagExitCode(err error) int {
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	return exitUsage
+}
+
+// newSearchHandler returns the handler for `hunt serve`
+func newSearchHandler(configs *configWatcher) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/search", http.StatusFound)
+	})
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		handleSearch(w, r, configs.Config())
+	})
+	mux.HandleFunc("GET /form", func(w http.ResponseWriter, r *http.Request) {
+		handleForm(w, r, configs.Config())
+	})
+	mux.HandleFunc("GET /opensearch.xml", openSearchDescriptorHandler("/search"))
+	return mux
+}
+
+// splitParams flattens repeated and comma-separated query parameter values
+func splitParams(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// requestCategories returns the categories named by the cat parameter, defaulting to "search"
+func requestCategories(query url.Values) []string {
+	categories := splitParams(query["cat"])
+	if len(categories) == 0 {
+		return []string{"search"}
+	}
+	for i, category := range categories {
+		// Accept the same aliases as the CLI subcommands (e.g., "shopping", "tech")
+		if mapped := mapSubcommandToCategory(strings.ToLower(category)); mapped != "" {
+			categories[i] = mapped
+		}
+	}
+	return categories
+}
+
+// handleSearch renders the landing page for the request's query and selections
+func handleSearch(w http.ResponseWriter, r *http.Request, config *hunt.Config) {
+	params := r.URL.Query()
+	query := params.Get("q")
+
+	selected, unmatched, err := hunt.SelectEngines(config, requestCategories(params), splitParams(params["s"]))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if len(unmatched) > 0 {
+		http.Error(w, fmt.Sprintf("unknown services: %s", strings.Join(unmatched, ", ")), http.StatusBadRequest)
+		return
+	}
+
+	links := make([]hunt.PageLink, len(selected))
+	for i, s := range selected {
+		engine := s.Engine
+		if hunt.BuildSearchRequest(engine, query).Method == hunt.MethodPost {
+			// Link through /form, which is a GET URL the page script can retarget like any other
+			engine = hunt.SearchEngine{Name: engine.Name, URL: "/form?e=" + url.QueryEscape(engine.Name) + "&q="}
+		}
+		links[i] = hunt.PageLink{Category: s.Category, Engine: engine, URL: hunt.BuildSearchURL(engine, query)}
+	}
+
+	// Advertise a descriptor that keeps this page's category and service selection
+	descriptor := url.URL{Path: "/opensearch.xml"}
+	filter := url.Values{}
+	for _, key := range []string{"cat", "s"} {
+		if values, ok := params[key]; ok {
+			filter[key] = values
+		}
+	}
+	descriptor.RawQuery = filter.Encode()
+
+	page := hunt.LandingPage{Query: query, Links: links, OpenSearchURL: descriptor.String()}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Render(w); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to render search page: %v\n", err)
+	}
+}
+
+// handleForm serves the auto-submitting form page for a POST search
+// The engine is named by e and the query by q.
+func handleForm(w http.ResponseWriter, r *http.Request, config *hunt.Config) {
+	params := r.URL.Query()
+	engine, ok := config.FindEngine(params.Get("e"))
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown service %q", params.Get("e")), http.StatusNotFound)
+		return
+	}
+	writeFormPage(w, engine.Name, hunt.BuildSearchRequest(engine, params.Get("q")))
+}
+
+// writeFormPage responds with a page that submits a POST search as soon as it loads
+func writeFormPage(w http.ResponseWriter, name string, req hunt.SearchRequest) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := hunt.WriteFormPage(w, name, req); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write form page: %v\n", err)
+	}
+}
+
+// openSearchDescriptorHandler serves an OpenSearch descriptor pointing browsers at searchPath
+// Any cat and s parameters are carried over into the search URL template
+func openSearchDescriptorHandler(searchPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeOpenSearchDescriptor(w, r, searchPath)
+	}
+}
+
+// writeOpenSearchDescriptor writes the descriptor for a request to openSearchDescriptorHandler
+func writeOpenSearchDescriptor(w http.ResponseWriter, r *http.Request, searchPath string) {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	filter := url.Values{}
+	for _, key := range []string{"cat", "s"} {
+		if values, ok := r.URL.Query()[key]; ok {
+			filter[key] = values
+		}
+	}
+
+	// {searchTerms} must stay unescaped, so it is appended after encoding the fixed parameters
+	template := scheme + "://" + r.Host + searchPath + "?"
+	if encoded := filter.Encode(); encoded != "" {
+		template += encoded + "&"
+	}
+	template += "q={searchTerms}"
+
+	desc := hunt.OpenSearchDescription{
+		ShortName:     "hunt",
+		Description:   "Search every configured hunt service at once",
+		InputEncoding: "UTF-8",
+		URLs:          []hunt.OpenSearchURL{{Type: "text/html", Method: "get", Template: template}},
+	}
+
+	w.Header().Set("Content-Type", "application/opensearchdescription+xml")
+	if err := hunt.WriteOpenSearchDescription(w, desc); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write OpenSearch descriptor: %v\n", err)
+	}
+}
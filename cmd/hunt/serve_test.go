@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aneely/hunt"
+)
+
+const serveTestConfig = `{
+  "search": [
+    {"name": "Bing", "url": "https://www.bing.com/search?q=", "space_delimiter": "+"},
+    {"name": "Google", "url": "https://www.google.com/search?q=", "space_delimiter": "+"}
+  ],
+  "shop": [
+    {"name": "Swappa", "url": "https://swappa.com/search?q=", "space_delimiter": "%20"}
+  ]
+}`
+
+// newTestConfigWatcher writes contents to a temporary search_engines.json and watches it
+func newTestConfigWatcher(t *testing.T, contents string) *configWatcher {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "search_engines.json")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	configs, err := newConfigWatcher(path, &strings.Builder{})
+	if err != nil {
+		t.Fatalf("newConfigWatcher() error = %v", err)
+	}
+	return configs
+}
+
+func TestSearchHandler(t *testing.T) {
+	handler := newSearchHandler(newTestConfigWatcher(t, serveTestConfig))
+
+	tests := []struct {
+		name         string
+		target       string
+		wantStatus   int
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name:       "default category",
+			target:     "/search?q=machine+learning",
+			wantStatus: http.StatusOK,
+			wantContains: []string{
+				"https://www.bing.com/search?q=machine&#43;learning",
+				"https://www.google.com/search?q=machine&#43;learning",
+				`href="/opensearch.xml"`,
+			},
+			wantMissing: []string{"swappa.com"},
+		},
+		{
+			name:         "category and service selection",
+			target:       "/search?q=laptop&cat=search,shop&s=google&s=swappa",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{"www.google.com", "swappa.com/search?q=laptop", `href="/opensearch.xml?cat=search%2cshop&amp;s=google&amp;s=swappa"`},
+			wantMissing:  []string{"www.bing.com"},
+		},
+		{
+			name:         "subcommand alias",
+			target:       "/search?q=laptop&cat=shopping",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{"swappa.com/search?q=laptop"},
+		},
+		{
+			name:       "unknown category",
+			target:     "/search?q=x&cat=nope",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:         "unknown service",
+			target:       "/search?q=x&s=altavista",
+			wantStatus:   http.StatusBadRequest,
+			wantContains: []string{"altavista"},
+		},
+		{
+			name:       "root redirects",
+			target:     "/",
+			wantStatus: http.StatusFound,
+		},
+		{
+			name:       "wrong method",
+			target:     "/search?q=x",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodGet
+			if tt.wantStatus == http.StatusMethodNotAllowed {
+				method = http.MethodPost
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, tt.target, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("GET %s status = %d, want %d\n%s", tt.target, rec.Code, tt.wantStatus, rec.Body)
+			}
+			body := strings.ToLower(rec.Body.String())
+			for _, want := range tt.wantContains {
+				if !strings.Contains(body, strings.ToLower(want)) {
+					t.Errorf("GET %s body missing %q", tt.target, want)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(body, strings.ToLower(unwanted)) {
+					t.Errorf("GET %s body unexpectedly contains %q", tt.target, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenSearchDescriptor(t *testing.T) {
+	handler := newSearchHandler(newTestConfigWatcher(t, serveTestConfig))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/opensearch.xml?cat=shop", nil)
+	req.Host = "localhost:8080"
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/opensearchdescription+xml" {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	var desc hunt.OpenSearchDescription
+	if err := xml.Unmarshal(rec.Body.Bytes(), &desc); err != nil {
+		t.Fatalf("descriptor is not valid XML: %v\n%s", err, rec.Body)
+	}
+	if desc.ShortName != "hunt" {
+		t.Errorf("ShortName = %q, want %q", desc.ShortName, "hunt")
+	}
+	want := "http://localhost:8080/search?cat=shop&q={searchTerms}"
+	if len(desc.URLs) != 1 || desc.URLs[0].Template != want {
+		t.Errorf("URLs = %+v, want one with template %q", desc.URLs, want)
+	}
+}
+
+func TestConfigWatcher_Reload(t *testing.T) {
+	var log strings.Builder
+	configs := newTestConfigWatcher(t, serveTestConfig)
+	configs.log = &log
+
+	// Unchanged file: nothing happens
+	original := configs.Config()
+	configs.reloadIfChanged()
+	if configs.Config() != original {
+		t.Errorf("reloadIfChanged() replaced an unchanged config")
+	}
+
+	// Broken file: previous config is kept and the failure is reported
+	if err := os.WriteFile(configs.path, []byte(`{"search": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configs.reloadIfChanged()
+	if configs.Config() != original {
+		t.Errorf("reloadIfChanged() replaced the config with an invalid one")
+	}
+	if !strings.Contains(log.String(), "keeping previous config") {
+		t.Errorf("reload failure not logged, got %q", log.String())
+	}
+
+	// Valid edit: picked up
+	updated := `{"news": [{"name": "Reuters", "url": "https://www.reuters.com/search?q="}]}`
+	if err := os.WriteFile(configs.path, []byte(updated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configs.reloadIfChanged()
+	if engines := configs.Config().GetEnginesByCategory("news"); len(engines) != 1 || engines[0].Name != "Reuters" {
+		t.Errorf("reloadIfChanged() did not load the edited config, news = %+v", engines)
+	}
+}
+
+func TestSearchHandler_PostEngine(t *testing.T) {
+	handler := newSearchHandler(newTestConfigWatcher(t, `{
+  "books": [
+    {"name": "Catalog", "url": "https://catalog.example.edu/search", "method": "POST", "form": [{"name": "terms", "value": "{query}"}]}
+  ]
+}`))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search?q=dune&cat=books", nil))
+	if want := `href="/form?e=Catalog&amp;q=dune"`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("search page missing %s", want)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/form?e=catalog&q=dune", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `<input type="hidden" name="terms" value="dune">`) {
+		t.Errorf("form page = %d:\n%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/form?e=altavista&q=dune", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown engine status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aneely/hunt"
+)
+
+// configReloadInterval is how often HTTP commands check search_engines.json for changes
+const configReloadInterval = 2 * time.Second
+
+// configWatcher holds the most recently loaded config and reloads it when the file changes
+// A reload that fails keeps the previous config, so a half-saved file never takes a server down.
+type configWatcher struct {
+	path string
+	log  io.Writer
+
+	mu      sync.RWMutex
+	config  *hunt.Config
+	modTime time.Time
+	size    int64
+}
+
+// newConfigWatcher loads the config at path
+func newConfigWatcher(path string, log io.Writer) (*configWatcher, error) {
+	w := &configWatcher{path: path, log: log}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search_engines.json: %w", err)
+	}
+	config, err := hunt.LoadConfig(hunt.WithFiles(path))
+	if err != nil {
+		return nil, err
+	}
+	w.config, w.modTime, w.size = config, info.ModTime(), info.Size()
+	return w, nil
+}
+
+// Config returns the current config
+func (w *configWatcher) Config() *hunt.Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config
+}
+
+// reloadIfChanged reloads the config if the file's modification time or size changed
+func (w *configWatcher) reloadIfChanged() {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return
+	}
+
+	w.mu.RLock()
+	unchanged := info.ModTime().Equal(w.modTime) && info.Size() == w.size
+	w.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	config, err := hunt.LoadConfig(hunt.WithFiles(w.path))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// Remember the attempt either way so a broken file is only reported once
+	w.modTime, w.size = info.ModTime(), info.Size()
+	if err != nil {
+		fmt.Fprintf(w.log, "Warning: keeping previous config, reload of %s failed: %v\n", w.path, err)
+		return
+	}
+	w.config = config
+	fmt.Fprintf(w.log, "Reloaded %s\n", w.path)
+}
+
+// watch polls for config changes until ctx is cancelled
+func (w *configWatcher) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.reloadIfChanged()
+		}
+	}
+}
+
+// runServer loads and watches the config, then serves the handler built by newHandler on addr
+// until SIGINT or SIGTERM, shutting down gracefully. Returns the process exit code.
+func runServer(name, addr string, newHandler func(configs *configWatcher) http.Handler) int {
+	configs, code := loadConfigWatcher()
+	if configs == nil {
+		return code
+	}
+	return serveUntilSignal(name, addr, configs, newHandler(configs))
+}
+
+// loadConfigWatcher loads the config a server watches, reporting any error to stderr
+// Returns nil and the process exit code if the config can't be loaded.
+func loadConfigWatcher() (*configWatcher, int) {
+	jsonPath, err := hunt.ConfigPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, exitConfig
+	}
+
+	configs, err := newConfigWatcher(jsonPath, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, exitConfig
+	}
+	return configs, exitOK
+}
+
+// serveUntilSignal serves handler on addr until SIGINT or SIGTERM
+func serveUntilSignal(name, addr string, configs *configWatcher, handler http.Handler) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	go configs.watch(ctx, configReloadInterval)
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+	fmt.Printf("hunt %s listening on http://%s (config: %s)\n", name, listener.Addr(), configs.path)
+
+	select {
+	case err := <-served:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
@@ -1,37 +1,180 @@
-package main
+package hunt
 
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 )
 
 // SearchEngine represents a single search engine configuration
 type SearchEngine struct {
-	Name           string `json:"name"`
-	URL            string `json:"url"`
-	SpaceDelimiter string `json:"space_delimiter"`
+	Name           string           `json:"name"`
+	URL            string           `json:"url"`
+	Encoding       Encoding         `json:"encoding,omitzero"`         // Also read from the legacy "space_delimiter" field
+	Operators      OperatorDialect  `json:"operators,omitzero"`        // How search operators such as site: are sent
+	QueryTransform []QueryTransform `json:"query_transform,omitempty"` // Applied in order before encoding
+	Private        bool             `json:"private,omitempty"`         // Always open in a private/incognito window
+	Extends        string           `json:"extends,omitempty"`         // "category:name" of an engine whose unset fields this one inherits
+
+	// Verticals maps a vertical (e.g., "images") to the URL used instead of URL for it
+	Verticals map[string]string `json:"verticals,omitempty"`
+
+	// Search options: how --past, --since, --until, --lang, --region, --safe, --results-page,
+	// --num and the shopping filters map onto the URL
+	Date       DateFilter       `json:"date,omitzero"`
+	Locale     LocaleFilter     `json:"locale,omitzero"`
+	SafeSearch SafeSearchFilter `json:"safe_search,omitempty"`
+	Paging     PagingFilter     `json:"paging,omitzero"`
+	Facets     ShopFacets       `json:"facets,omitzero"`
+
+	// POST engines: URL is the form action and the query fills {query} in Form values
+	Method       string      `json:"method,omitempty"`
+	Form         []FormField `json:"form,omitempty"`
+	MaxURLLength int         `json:"max_url_length,omitempty"` // Longer GET URLs are POSTed as a form instead
+}
+
+// OpenSettings holds the tab-opening defaults from the "settings" block
+// Durations use Go duration syntax (e.g., "300ms", "2s")
+type OpenSettings struct {
+	Strategy    string `json:"strategy,omitempty"`
+	Delay       string `json:"delay,omitempty"`
+	Concurrency int    `json:"concurrency,omitempty"`
+	Timeout     string `json:"timeout,omitempty"`
+}
+
+// RedirectSettings holds the defaults for `hunt redirect`
+type RedirectSettings struct {
+	DefaultEngine string `json:"default_engine,omitempty"` // Engine used when the first word names no engine
 }
 
+// Settings holds non-engine configuration stored under the "settings" key
+type Settings struct {
+	Open       OpenSettings     `json:"open"`
+	Redirect   RedirectSettings `json:"redirect"`
+	Locale     LocaleSettings   `json:"locale"`                // Defaults for --lang and --region
+	SafeSearch string           `json:"safe_search,omitempty"` // Default for --safe
+}
+
+// settingsKey is the reserved top-level JSON key for Settings; it is never treated as a category
+const settingsKey = "settings"
+
 // Config holds the application configuration
 type Config struct {
 	Categories map[string][]SearchEngine `json:"-"`
+	Settings   Settings                  `json:"-"`
+}
+
+// ConfigOption selects a source for LoadConfig
+type ConfigOption func(*configLoader)
+
+// configLoader collects the sources LoadConfig reads, in order
+type configLoader struct {
+	sources []configSource
+}
+
+// configSource is one search_engines.json document
+type configSource struct {
+	name string // Shown in errors when several sources are merged
+	read func() ([]byte, error)
+}
+
+// WithBytes loads configuration from the contents of a search_engines.json file
+func WithBytes(data []byte) ConfigOption {
+	return func(l *configLoader) {
+		l.sources = append(l.sources, configSource{name: "config bytes", read: func() ([]byte, error) {
+			return data, nil
+		}})
+	}
+}
+
+// WithReader loads configuration from r, which is read when LoadConfig is called
+func WithReader(r io.Reader) ConfigOption {
+	return func(l *configLoader) {
+		l.sources = append(l.sources, configSource{name: "config reader", read: func() ([]byte, error) {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read config: %w", err)
+			}
+			return data, nil
+		}})
+	}
+}
+
+// WithFS loads configuration from the named file in fsys
+func WithFS(fsys fs.FS, name string) ConfigOption {
+	return func(l *configLoader) {
+		l.sources = append(l.sources, configSource{name: name, read: func() ([]byte, error) {
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read search_engines.json: %w", err)
+			}
+			return data, nil
+		}})
+	}
+}
+
+// WithFiles loads configuration from each file at paths, in order
+func WithFiles(paths ...string) ConfigOption {
+	return func(l *configLoader) {
+		for _, path := range paths {
+			l.sources = append(l.sources, configSource{name: path, read: func() ([]byte, error) {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read search_engines.json: %w", err)
+				}
+				return data, nil
+			}})
+		}
+	}
+}
+
+// LoadConfig loads search engines from the sources given as options
+// With no options it reads search_engines.json from ConfigPath.
+// Several sources are merged in order: categories are combined, an engine replaces an
+// earlier engine of the same name in the same category, and later settings override earlier ones.
+func LoadConfig(opts ...ConfigOption) (*Config, error) {
+	var loader configLoader
+	for _, opt := range opts {
+		opt(&loader)
+	}
+	if len(loader.sources) == 0 {
+		jsonPath, err := ConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		WithFiles(jsonPath)(&loader)
+	}
+
+	docs := make([]configDocument, len(loader.sources))
+	for i, source := range loader.sources {
+		data, err := source.read()
+		if err != nil {
+			return nil, err
+		}
+		docs[i] = configDocument{name: source.name, data: data}
+	}
+	return buildConfig(docs)
 }
 
-// LoadConfig loads search engines from the JSON file
-// It looks for search_engines.json in the same directory as the executable
-func LoadConfig() (*Config, error) {
+// ConfigPath returns the default search_engines.json path used by LoadConfig
+// It prefers the executable's directory and falls back to the current directory
+func ConfigPath() (string, error) {
 	// Get the directory where the executable is located
 	execPath, err := os.Executable()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get executable path: %w", err)
+		return "", fmt.Errorf("failed to get executable path: %w", err)
 	}
 
 	// Resolve symlinks to get the actual path
 	execPath, err = filepath.EvalSymlinks(execPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve executable symlinks: %w", err)
+		return "", fmt.Errorf("failed to resolve executable symlinks: %w", err)
 	}
 
 	execDir := filepath.Dir(execPath)
@@ -44,23 +187,55 @@ func LoadConfig() (*Config, error) {
 		jsonPath = filepath.Join(cwd, "search_engines.json")
 	}
 
-	// Read the JSON file
-	data, err := os.ReadFile(jsonPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read search_engines.json: %w", err)
+	return jsonPath, nil
+}
+
+// configDocument is the contents of one configuration source
+type configDocument struct {
+	name string
+	data []byte
+}
+
+// parseConfig parses and validates the contents of a single search_engines.json file
+func parseConfig(data []byte) (*Config, error) {
+	return buildConfig([]configDocument{{data: data}})
+}
+
+// buildConfig parses, merges and validates configuration documents
+func buildConfig(docs []configDocument) (*Config, error) {
+	var settings Settings
+	objects := make(map[string][]engineObject)
+	for _, doc := range docs {
+		if err := mergeDocument(doc.data, &settings, objects); err != nil {
+			if len(docs) > 1 {
+				return nil, fmt.Errorf("%s: %w", doc.name, err)
+			}
+			return nil, err
+		}
 	}
 
-	// Parse JSON - new structure with category keys
-	var categoriesData map[string][]SearchEngine
-	if err := json.Unmarshal(data, &categoriesData); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	if _, err := settings.Open.Apply(DefaultOpenOptions()); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	if err := settings.Locale.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	if settings.SafeSearch != "" {
+		if _, err := ParseSafeSearch(settings.SafeSearch); err != nil {
+			return nil, fmt.Errorf("invalid settings: %w", err)
+		}
 	}
 
 	// Validate and set defaults
-	if len(categoriesData) == 0 {
+	if len(objects) == 0 {
 		return nil, fmt.Errorf("no categories found in JSON file")
 	}
 
+	categoriesData, err := resolveEngines(objects)
+	if err != nil {
+		return nil, err
+	}
+
 	categories := make(map[string][]SearchEngine)
 	for category, engines := range categoriesData {
 		if len(engines) == 0 {
@@ -69,11 +244,6 @@ func LoadConfig() (*Config, error) {
 
 		validatedEngines := make([]SearchEngine, 0, len(engines))
 		for i := range engines {
-			// Default space delimiter to "+" if not specified
-			if engines[i].SpaceDelimiter == "" {
-				engines[i].SpaceDelimiter = "+"
-			}
-
 			// Validate required fields
 			if engines[i].Name == "" {
 				return nil, fmt.Errorf("engine in category %q at index %d has no name", category, i)
@@ -81,6 +251,41 @@ func LoadConfig() (*Config, error) {
 			if engines[i].URL == "" {
 				return nil, fmt.Errorf("engine in category %q at index %d has no URL", category, i)
 			}
+			if err := engines[i].Encoding.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].validateMethod(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].Operators.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].Date.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].Locale.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].SafeSearch.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].Paging.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].Facets.Validate(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			if err := engines[i].validateVerticals(); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+			}
+			for j := range engines[i].QueryTransform {
+				if err := engines[i].QueryTransform[j].Validate(); err != nil {
+					return nil, fmt.Errorf("engine %q in category %q: %w", engines[i].Name, category, err)
+				}
+			}
+
+			// Default the space delimiter ("+", or "%20" for path escaping) if not specified
+			engines[i].Encoding = engines[i].Encoding.withDefaults()
 
 			validatedEngines = append(validatedEngines, engines[i])
 		}
@@ -94,7 +299,146 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("no valid engines found in any category")
 	}
 
-	return &Config{Categories: categories}, nil
+	config := &Config{Categories: categories, Settings: settings}
+	if name := settings.Redirect.DefaultEngine; name != "" {
+		if _, ok := config.FindEngine(name); !ok {
+			return nil, fmt.Errorf("invalid settings: redirect default engine %q not found", name)
+		}
+	}
+
+	return config, nil
+}
+
+// mergeDocument parses one document into settings and categories
+// Settings present in the document override those already set; engines are appended
+// to their category, replacing any earlier engine with the same name
+func mergeDocument(data []byte, settings *Settings, categories map[string][]engineObject) error {
+	// Parse JSON - new structure with category keys, plus an optional settings block
+	var rawData map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawData); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	if rawSettings, ok := rawData[settingsKey]; ok {
+		if err := json.Unmarshal(rawSettings, settings); err != nil {
+			return fmt.Errorf("failed to parse settings: %w", err)
+		}
+		delete(rawData, settingsKey)
+	}
+
+	for category, rawEngines := range rawData {
+		// Engines stay raw until extends is resolved; decoding them here still reports type errors
+		var engines []SearchEngine
+		if err := json.Unmarshal(rawEngines, &engines); err != nil {
+			return fmt.Errorf("failed to parse category %q: %w", category, err)
+		}
+		var engineObjects []engineObject
+		if err := json.Unmarshal(rawEngines, &engineObjects); err != nil {
+			return fmt.Errorf("failed to parse category %q: %w", category, err)
+		}
+
+		merged := categories[category]
+		for j, engine := range engines {
+			replaced := false
+			for i := range merged {
+				if engine.Name != "" && strings.EqualFold(merged[i].name(), engine.Name) {
+					merged[i] = engineObjects[j]
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				merged = append(merged, engineObjects[j])
+			}
+		}
+		if merged == nil {
+			merged = []engineObject{}
+		}
+		categories[category] = merged
+	}
+	return nil
+}
+
+// Apply overlays these settings onto opts, returning the result
+// Unset fields leave the corresponding option unchanged
+func (s OpenSettings) Apply(opts OpenOptions) (OpenOptions, error) {
+	if s.Strategy != "" {
+		strategy, err := ParseOpenStrategy(s.Strategy)
+		if err != nil {
+			return opts, err
+		}
+		opts.Strategy = strategy
+	}
+	if s.Delay != "" {
+		delay, err := time.ParseDuration(s.Delay)
+		if err != nil || delay < 0 {
+			return opts, fmt.Errorf("invalid open delay %q", s.Delay)
+		}
+		opts.Delay = delay
+	}
+	if s.Concurrency < 0 {
+		return opts, fmt.Errorf("invalid open concurrency %d", s.Concurrency)
+	}
+	if s.Concurrency > 0 {
+		opts.Concurrency = s.Concurrency
+	}
+	if s.Timeout != "" {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil || timeout <= 0 {
+			return opts, fmt.Errorf("invalid open timeout %q", s.Timeout)
+		}
+		opts.Timeout = timeout
+	}
+	return opts, nil
+}
+
+// FormatCategoryName formats a category name for display
+func FormatCategoryName(category string) string {
+	switch category {
+	case "search":
+		return "Search Engines"
+	case "shop":
+		return "Shopping Sites"
+	case "technews":
+		return "Tech News"
+	case "news":
+		return "News"
+	default:
+		// Capitalize first letter and add "s" if needed
+		if len(category) == 0 {
+			return category
+		}
+		return strings.ToUpper(string(category[0])) + category[1:] + " Services"
+	}
+}
+
+// FindEngine returns the first engine named name (case-insensitive), searching the "search"
+// category first and then the remaining categories in alphabetical order
+func (c *Config) FindEngine(name string) (SearchEngine, bool) {
+	for _, category := range c.CategoryNames() {
+		engines := c.Categories[category]
+		for _, engine := range engines {
+			if strings.EqualFold(engine.Name, name) {
+				return engine, true
+			}
+		}
+	}
+	return SearchEngine{}, false
+}
+
+// CategoryNames returns the category names with "search" first and the rest sorted
+func (c *Config) CategoryNames() []string {
+	categories := make([]string, 0, len(c.Categories))
+	for category := range c.Categories {
+		if category != "search" {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	if _, ok := c.Categories["search"]; ok {
+		categories = append([]string{"search"}, categories...)
+	}
+	return categories
 }
 
 // GetEnginesByCategory returns engines for a specific category
@@ -1,9 +1,11 @@
-package main
+package hunt
 
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -52,8 +54,8 @@ func TestLoadConfig(t *testing.T) {
 	if engines[0].URL != "https://www.bing.com/search?q=" {
 		t.Errorf("LoadConfig() first engine URL = %q, want %q", engines[0].URL, "https://www.bing.com/search?q=")
 	}
-	if engines[0].SpaceDelimiter != "+" {
-		t.Errorf("LoadConfig() first engine delimiter = %q, want %q", engines[0].SpaceDelimiter, "+")
+	if engines[0].Encoding.Delimiter != "+" {
+		t.Errorf("LoadConfig() first engine delimiter = %q, want %q", engines[0].Encoding.Delimiter, "+")
 	}
 }
 
@@ -88,8 +90,8 @@ func TestLoadConfig_DefaultDelimiter(t *testing.T) {
 	}
 
 	engines := config.GetEnginesByCategory("search")
-	if engines[0].SpaceDelimiter != "+" {
-		t.Errorf("LoadConfig() default delimiter = %q, want %q", engines[0].SpaceDelimiter, "+")
+	if engines[0].Encoding.Delimiter != "+" {
+		t.Errorf("LoadConfig() default delimiter = %q, want %q", engines[0].Encoding.Delimiter, "+")
 	}
 }
 
@@ -124,8 +126,8 @@ func TestLoadConfig_EmptyDelimiter(t *testing.T) {
 	}
 
 	engines := config.GetEnginesByCategory("search")
-	if engines[0].SpaceDelimiter != "+" {
-		t.Errorf("LoadConfig() empty delimiter default = %q, want %q", engines[0].SpaceDelimiter, "+")
+	if engines[0].Encoding.Delimiter != "+" {
+		t.Errorf("LoadConfig() empty delimiter default = %q, want %q", engines[0].Encoding.Delimiter, "+")
 	}
 }
 
@@ -261,8 +263,8 @@ func TestLoadConfig_ValidatesJSONStructure(t *testing.T) {
 	}
 
 	// Verify second engine has custom delimiter
-	if engines[1].SpaceDelimiter != "%20" {
-		t.Errorf("LoadConfig() second engine delimiter = %q, want %q", engines[1].SpaceDelimiter, "%20")
+	if engines[1].Encoding.Delimiter != "%20" {
+		t.Errorf("LoadConfig() second engine delimiter = %q, want %q", engines[1].Encoding.Delimiter, "%20")
 	}
 }
 
@@ -334,7 +336,282 @@ func TestLoadConfig_MultipleCategories(t *testing.T) {
 	if newsEngines[0].Name != "NPR" {
 		t.Errorf("LoadConfig() news category engine name = %q, want %q", newsEngines[0].Name, "NPR")
 	}
-	if newsEngines[0].SpaceDelimiter != "%20" {
-		t.Errorf("LoadConfig() news category delimiter = %q, want %q", newsEngines[0].SpaceDelimiter, "%20")
+	if newsEngines[0].Encoding.Delimiter != "%20" {
+		t.Errorf("LoadConfig() news category delimiter = %q, want %q", newsEngines[0].Encoding.Delimiter, "%20")
+	}
+}
+
+func TestLoadConfig_PrivateEngine(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "search_engines.json")
+
+	privateJSON := `{
+		"search": [
+			{"name": "Bing", "url": "https://www.bing.com/search?q="},
+			{"name": "Private", "url": "https://private.example/search?q=", "private": true}
+		]
+	}`
+
+	if err := os.WriteFile(jsonPath, []byte(privateJSON), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(oldDir)
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+
+	engines := config.GetEnginesByCategory("search")
+	if engines[0].Private {
+		t.Errorf("LoadConfig() engine %q private = true, want false", engines[0].Name)
+	}
+	if !engines[1].Private {
+		t.Errorf("LoadConfig() engine %q private = false, want true", engines[1].Name)
+	}
+}
+
+func TestLoadConfig_Settings(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "search_engines.json")
+
+	tests := []struct {
+		name    string
+		json    string
+		want    OpenSettings
+		wantErr bool
+	}{
+		{
+			name: "open settings",
+			json: `{
+				"settings": {"open": {"strategy": "adaptive", "delay": "100ms", "concurrency": 2, "timeout": "2s"}},
+				"search": [{"name": "Bing", "url": "https://www.bing.com/search?q="}]
+			}`,
+			want: OpenSettings{Strategy: "adaptive", Delay: "100ms", Concurrency: 2, Timeout: "2s"},
+		},
+		{
+			name: "no settings",
+			json: `{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q="}]}`,
+			want: OpenSettings{},
+		},
+		{
+			name: "invalid strategy",
+			json: `{
+				"settings": {"open": {"strategy": "warp"}},
+				"search": [{"name": "Bing", "url": "https://www.bing.com/search?q="}]
+			}`,
+			wantErr: true,
+		},
+		{
+			name:    "settings only",
+			json:    `{"settings": {"open": {"strategy": "sequential"}}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(jsonPath, []byte(tt.json), 0644); err != nil {
+				t.Fatalf("Failed to create test JSON file: %v", err)
+			}
+
+			oldDir, err := os.Getwd()
+			if err != nil {
+				t.Fatalf("Failed to get current directory: %v", err)
+			}
+			defer os.Chdir(oldDir)
+
+			if err := os.Chdir(tmpDir); err != nil {
+				t.Fatalf("Failed to change to temp directory: %v", err)
+			}
+
+			config, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("LoadConfig() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v, want nil", err)
+			}
+			if config.Settings.Open != tt.want {
+				t.Errorf("LoadConfig() open settings = %+v, want %+v", config.Settings.Open, tt.want)
+			}
+			if _, ok := config.Categories["settings"]; ok {
+				t.Error("LoadConfig() treated settings as a category")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RedirectDefaultEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{"known engine", `{"settings": {"redirect": {"default_engine": "bing"}}, "search": [{"name": "Bing", "url": "https://www.bing.com/search?q="}]}`, false},
+		{"unknown engine", `{"settings": {"redirect": {"default_engine": "AltaVista"}}, "search": [{"name": "Bing", "url": "https://www.bing.com/search?q="}]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatCategoryName(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		want     string
+	}{
+		{
+			name:     "search category",
+			category: "search",
+			want:     "Search Engines",
+		},
+		{
+			name:     "shop category",
+			category: "shop",
+			want:     "Shopping Sites",
+		},
+		{
+			name:     "technews category",
+			category: "technews",
+			want:     "Tech News",
+		},
+		{
+			name:     "news category",
+			category: "news",
+			want:     "News",
+		},
+		{
+			name:     "unknown category",
+			category: "unknown",
+			want:     "Unknown Services",
+		},
+		{
+			name:     "empty category",
+			category: "",
+			want:     "",
+		},
+		{
+			name:     "single letter category",
+			category: "x",
+			want:     "X Services",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatCategoryName(tt.category)
+			if got != tt.want {
+				t.Errorf("FormatCategoryName(%q) = %q, want %q", tt.category, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Options(t *testing.T) {
+	base := `{
+		"settings": {"open": {"strategy": "adaptive", "delay": "100ms"}},
+		"search": [
+			{"name": "Bing", "url": "https://www.bing.com/search?q="},
+			{"name": "Google", "url": "https://www.google.com/search?q="}
+		]
+	}`
+	override := `{
+		"settings": {"open": {"delay": "50ms"}},
+		"search": [{"name": "google", "url": "https://www.google.co.uk/search?q="}],
+		"shop": [{"name": "Amazon", "url": "https://www.amazon.com/s?k="}]
+	}`
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.json")
+	overridePath := filepath.Join(dir, "override.json")
+	if err := os.WriteFile(basePath, []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(overridePath, []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{"config/search_engines.json": {Data: []byte(base)}}
+
+	tests := []struct {
+		name       string
+		opts       []ConfigOption
+		wantSearch []string
+		wantShop   int
+		wantDelay  string
+		wantErr    bool
+	}{
+		{"bytes", []ConfigOption{WithBytes([]byte(base))}, []string{"https://www.bing.com/search?q=", "https://www.google.com/search?q="}, 0, "100ms", false},
+		{"reader", []ConfigOption{WithReader(strings.NewReader(base))}, []string{"https://www.bing.com/search?q=", "https://www.google.com/search?q="}, 0, "100ms", false},
+		{"fs", []ConfigOption{WithFS(fsys, "config/search_engines.json")}, []string{"https://www.bing.com/search?q=", "https://www.google.com/search?q="}, 0, "100ms", false},
+		{"files merged in order", []ConfigOption{WithFiles(basePath, overridePath)}, []string{"https://www.bing.com/search?q=", "https://www.google.co.uk/search?q="}, 1, "50ms", false},
+		{"mixed sources", []ConfigOption{WithFiles(basePath), WithBytes([]byte(override))}, []string{"https://www.bing.com/search?q=", "https://www.google.co.uk/search?q="}, 1, "50ms", false},
+		{"missing file", []ConfigOption{WithFiles(filepath.Join(dir, "missing.json"))}, nil, 0, "", true},
+		{"missing fs file", []ConfigOption{WithFS(fsys, "nope.json")}, nil, 0, "", true},
+		{"invalid second source", []ConfigOption{WithBytes([]byte(base)), WithBytes([]byte(`{`))}, nil, 0, "", true},
+		{"date: valid", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "date": {"past": {"week": {"freshness": "Week"}}}}]}`))}, nil, 0, "", false},
+		{"date: unknown period", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "date": {"past": {"fortnight": {"freshness": "Week"}}}}]}`))}, nil, 0, "", true},
+		{"date: period without parameters", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "date": {"past": {"week": {}}}}]}`))}, nil, 0, "", true},
+		{"locale: valid", []ConfigOption{WithBytes([]byte(`{"settings": {"locale": {"lang": "de", "region": "DE"}}, "shop": [{"name": "Amazon", "url": "https://www.amazon.com/s?k=", "locale": {"domains": {"DE": "www.amazon.de"}}}]}`))}, nil, 0, "", false},
+		{"locale: invalid default region", []ConfigOption{WithBytes([]byte(`{"settings": {"locale": {"region": "Germany"}}, "shop": [{"name": "Amazon", "url": "https://www.amazon.com/s?k="}]}`))}, nil, 0, "", true},
+		{"locale: lowercase domain key", []ConfigOption{WithBytes([]byte(`{"shop": [{"name": "Amazon", "url": "https://www.amazon.com/s?k=", "locale": {"domains": {"de": "www.amazon.de"}}}]}`))}, nil, 0, "", true},
+		{"locale: empty region code", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "DuckDuckGo", "url": "https://duckduckgo.com/?q=", "locale": {"regions": {"GB": ""}}}]}`))}, nil, 0, "", true},
+		{"safe search: valid", []ConfigOption{WithBytes([]byte(`{"settings": {"safe_search": "strict"}, "search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "safe_search": {"strict": {"adlt": "strict"}}}]}`))}, nil, 0, "", false},
+		{"safe search: invalid default", []ConfigOption{WithBytes([]byte(`{"settings": {"safe_search": "on"}, "search": [{"name": "Bing", "url": "https://www.bing.com/search?q="}]}`))}, nil, 0, "", true},
+		{"safe search: unknown level", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "safe_search": {"high": {"adlt": "strict"}}}]}`))}, nil, 0, "", true},
+		{"safe search: level without parameters", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "safe_search": {"strict": {}}}]}`))}, nil, 0, "", true},
+		{"paging: valid", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Google", "url": "https://www.google.com/search?q=", "paging": {"page": {"start": "{offset}"}, "num": {"num": "{num}"}, "page_size": 10}}]}`))}, nil, 0, "", false},
+		{"paging: negative page size", []ConfigOption{WithBytes([]byte(`{"search": [{"name": "Google", "url": "https://www.google.com/search?q=", "paging": {"page": {"start": "{offset}"}, "page_size": -10}}]}`))}, nil, 0, "", true},
+		{"facets: valid", []ConfigOption{WithBytes([]byte(`{"shop": [{"name": "eBay", "url": "https://www.ebay.com/sch/i.html?_nkw=", "facets": {"sort": {"newest": {"_sop": "10"}}, "price": {"_udlo": "{min}"}, "condition": {"new": {"LH_ItemCondition": "1000"}}}}]}`))}, nil, 0, "", false},
+		{"facets: unknown sort order", []ConfigOption{WithBytes([]byte(`{"shop": [{"name": "eBay", "url": "https://www.ebay.com/sch/i.html?_nkw=", "facets": {"sort": {"cheapest": {"_sop": "15"}}}}]}`))}, nil, 0, "", true},
+		{"facets: unknown condition", []ConfigOption{WithBytes([]byte(`{"shop": [{"name": "eBay", "url": "https://www.ebay.com/sch/i.html?_nkw=", "facets": {"condition": {"mint": {"LH_ItemCondition": "1000"}}}}]}`))}, nil, 0, "", true},
+		{"facets: condition without parameters", []ConfigOption{WithBytes([]byte(`{"shop": [{"name": "eBay", "url": "https://www.ebay.com/sch/i.html?_nkw=", "facets": {"condition": {"new": {}}}}]}`))}, nil, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := LoadConfig(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr || tt.wantSearch == nil {
+				// Validation cases only check whether the config loads
+				return
+			}
+
+			engines := config.GetEnginesByCategory("search")
+			var urls []string
+			for _, engine := range engines {
+				urls = append(urls, engine.URL)
+			}
+			if strings.Join(urls, " ") != strings.Join(tt.wantSearch, " ") {
+				t.Errorf("search URLs = %v, want %v", urls, tt.wantSearch)
+			}
+			if got := len(config.GetEnginesByCategory("shop")); got != tt.wantShop {
+				t.Errorf("shop engines = %d, want %d", got, tt.wantShop)
+			}
+			if config.Settings.Open.Delay != tt.wantDelay || config.Settings.Open.Strategy != "adaptive" {
+				t.Errorf("open settings = %+v, want delay %q and strategy adaptive", config.Settings.Open, tt.wantDelay)
+			}
+		})
 	}
 }
@@ -0,0 +1,133 @@
+package hunt
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of --since and --until dates
+const DateLayout = "2006-01-02"
+
+// Periods for --past
+const (
+	PeriodHour  = "hour"
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// Periods lists the --past periods from shortest to longest
+var Periods = []string{PeriodHour, PeriodDay, PeriodWeek, PeriodMonth, PeriodYear}
+
+// DateFormatUnix formats {since} and {until} as Unix timestamps
+const DateFormatUnix = "unix"
+
+// DateFilter maps date restrictions onto an engine's URL parameters
+type DateFilter struct {
+	Past   map[string]map[string]string `json:"past,omitempty"`   // Period to parameters, e.g. "week": {"tbs": "qdr:w"}
+	Range  map[string]string            `json:"range,omitempty"`  // Parameters for --since/--until; values may use {since} and {until}
+	Format string                       `json:"format,omitempty"` // Go time layout for {since} and {until}, or "unix" (default 2006-01-02)
+}
+
+// ParsePeriod validates a --past period (case-insensitive) and returns its canonical form
+func ParsePeriod(name string) (string, error) {
+	for _, period := range Periods {
+		if strings.EqualFold(name, period) {
+			return period, nil
+		}
+	}
+	return "", fmt.Errorf("unknown period %q (want %s)", name, strings.Join(Periods, ", "))
+}
+
+// Validate checks the periods and that every parameter has a name
+func (d DateFilter) Validate() error {
+	for period, params := range d.Past {
+		if canonical, err := ParsePeriod(period); err != nil || canonical != period {
+			return fmt.Errorf("date: unknown period %q (want %s)", period, strings.Join(Periods, ", "))
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("date: period %q has no parameters", period)
+		}
+	}
+	for name := range d.Range {
+		if name == "" {
+			return fmt.Errorf("date: range parameter has no name")
+		}
+	}
+	return nil
+}
+
+// params returns the URL parameters restricting results to opts' dates
+// An engine without a mapping for --past uses its range instead, counting back from opts.Now.
+// Reports false if the engine can't filter by date at all.
+func (d DateFilter) params(opts SearchOptions) (url.Values, bool) {
+	now := opts.now()
+	since, until := opts.Since, opts.Until
+	if opts.Past != "" {
+		if params, ok := d.Past[opts.Past]; ok {
+			return toValues(params), true
+		}
+		since, until = periodStart(now, opts.Past), now
+	}
+	if len(d.Range) == 0 {
+		return nil, false
+	}
+	if since.IsZero() {
+		since = time.Unix(0, 0).UTC()
+	}
+	if until.IsZero() {
+		until = now
+	}
+
+	replacer := strings.NewReplacer("{since}", d.formatDate(since, false), "{until}", d.formatDate(until, true))
+	params := url.Values{}
+	for name, value := range d.Range {
+		params.Set(name, replacer.Replace(value))
+	}
+	return params, true
+}
+
+// formatDate formats t for {since} or {until}; a Unix {until} is the end of its day so the day is included
+func (d DateFilter) formatDate(t time.Time, until bool) string {
+	switch d.Format {
+	case "":
+		return t.Format(DateLayout)
+	case DateFormatUnix:
+		if until {
+			year, month, day := t.Date()
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, t.Location()).Add(-time.Second)
+		}
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(d.Format)
+	}
+}
+
+// periodStart returns the start of the --past period ending at now
+func periodStart(now time.Time, period string) time.Time {
+	switch period {
+	case PeriodHour:
+		return now.Add(-time.Hour)
+	case PeriodDay:
+		return now.AddDate(0, 0, -1)
+	case PeriodWeek:
+		return now.AddDate(0, 0, -7)
+	case PeriodMonth:
+		return now.AddDate(0, -1, 0)
+	default:
+		return now.AddDate(-1, 0, 0)
+	}
+}
+
+// toValues converts a parameter map from the config into url.Values
+func toValues(params map[string]string) url.Values {
+	values := url.Values{}
+	for name, value := range params {
+		values.Set(name, value)
+	}
+	return values
+}
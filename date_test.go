@@ -0,0 +1,58 @@
+package hunt
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDateFilter_Params(t *testing.T) {
+	google := DateFilter{
+		Past:   map[string]map[string]string{PeriodWeek: {"tbs": "qdr:w"}},
+		Range:  map[string]string{"tbs": "cdr:1,cd_min:{since},cd_max:{until}"},
+		Format: "1/2/2006",
+	}
+	hn := DateFilter{
+		Range:  map[string]string{"dateRange": "custom", "dateStart": "{since}", "dateEnd": "{until}"},
+		Format: DateFormatUnix,
+	}
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	date := func(s string) time.Time {
+		t, err := time.Parse(DateLayout, s)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+
+	tests := []struct {
+		name   string
+		filter DateFilter
+		opts   SearchOptions
+		want   url.Values
+		wantOK bool
+	}{
+		{"past period", google, SearchOptions{Past: PeriodWeek}, url.Values{"tbs": {"qdr:w"}}, true},
+		{"past falls back to range", google, SearchOptions{Past: PeriodMonth, Now: now}, url.Values{"tbs": {"cdr:1,cd_min:2/15/2024,cd_max:3/15/2024"}}, true},
+		{"since and until", google, SearchOptions{Since: date("2024-01-01"), Until: date("2024-01-31")}, url.Values{"tbs": {"cdr:1,cd_min:1/1/2024,cd_max:1/31/2024"}}, true},
+		{"until defaults to now", google, SearchOptions{Since: date("2024-01-01"), Now: now}, url.Values{"tbs": {"cdr:1,cd_min:1/1/2024,cd_max:3/15/2024"}}, true},
+		{"since defaults to the epoch", google, SearchOptions{Until: date("2024-01-31")}, url.Values{"tbs": {"cdr:1,cd_min:1/1/1970,cd_max:1/31/2024"}}, true},
+		{"unix includes the until day", hn, SearchOptions{Since: date("2024-01-01"), Until: date("2024-01-31")}, url.Values{"dateRange": {"custom"}, "dateStart": {"1704067200"}, "dateEnd": {"1706745599"}}, true},
+		{"default format", DateFilter{Range: map[string]string{"df": "{since}..{until}"}}, SearchOptions{Since: date("2024-01-01"), Until: date("2024-01-31")}, url.Values{"df": {"2024-01-01..2024-01-31"}}, true},
+		{"no mapping for the period", DateFilter{Past: map[string]map[string]string{PeriodDay: {"freshness": "Day"}}}, SearchOptions{Past: PeriodYear}, nil, false},
+		{"no date support", DateFilter{}, SearchOptions{Past: PeriodDay}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.filter.params(tt.opts)
+			if ok != tt.wantOK {
+				t.Fatalf("params() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("params() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
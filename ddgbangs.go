@@ -0,0 +1,64 @@
+package hunt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// duckDuckGoPlaceholder marks where the query goes in a DuckDuckGo bang URL
+const duckDuckGoPlaceholder = "{{{s}}}"
+
+// DuckDuckGoBang is one entry of DuckDuckGo's bang.js database
+type DuckDuckGoBang struct {
+	Trigger     string `json:"t"`  // e.g., "yt" for !yt
+	Name        string `json:"s"`  // Site name
+	Domain      string `json:"d"`  // Site domain
+	Category    string `json:"c"`  // e.g., "Multimedia"
+	Subcategory string `json:"sc"` // e.g., "Video"
+	URL         string `json:"u"`  // URL template containing {{{s}}}
+}
+
+// ParseDuckDuckGoBangs reads a bang.js file, a JSON array of bang entries
+func ParseDuckDuckGoBangs(r io.Reader) ([]DuckDuckGoBang, error) {
+	var bangs []DuckDuckGoBang
+	if err := json.NewDecoder(r).Decode(&bangs); err != nil {
+		return nil, fmt.Errorf("failed to parse bang.js: %w", err)
+	}
+	return bangs, nil
+}
+
+// InCategory reports whether the bang's category or subcategory matches any of categories
+// (case-insensitive). An empty list matches every bang.
+func (b DuckDuckGoBang) InCategory(categories []string) bool {
+	if len(categories) == 0 {
+		return true
+	}
+	for _, category := range categories {
+		if strings.EqualFold(category, b.Category) || strings.EqualFold(category, b.Subcategory) {
+			return true
+		}
+	}
+	return false
+}
+
+// Engine converts the bang into a SearchEngine
+// Only templates that end with the {{{s}}} placeholder fit hunt's URL-prefix model.
+func (b DuckDuckGoBang) Engine() (SearchEngine, error) {
+	name := strings.TrimSpace(b.Name)
+	if name == "" {
+		return SearchEngine{}, fmt.Errorf("no site name")
+	}
+	// DuckDuckGo templates are relative when they point back at DuckDuckGo itself
+	template := b.URL
+	if strings.HasPrefix(template, "/") {
+		template = "https://duckduckgo.com" + template
+	}
+	prefix, ok := TemplateToPrefix(template, duckDuckGoPlaceholder)
+	if !ok {
+		return SearchEngine{}, fmt.Errorf("query placeholder is not at the end of %s", b.URL)
+	}
+	// %20 is a safe space encoding whether the query lands in the path or the query string
+	return SearchEngine{Name: name, URL: prefix, Encoding: Encoding{Delimiter: "%20"}}, nil
+}
@@ -0,0 +1,58 @@
+package hunt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDuckDuckGoBangs(t *testing.T) {
+	bangJS := `[
+		{"c": "Multimedia", "d": "www.youtube.com", "r": 0, "s": "YouTube", "sc": "Video", "t": "yt", "u": "https://www.youtube.com/results?search_query={{{s}}}"},
+		{"c": "Multimedia", "d": "vimeo.com", "s": "Vimeo", "sc": "Video", "t": "vimeo", "u": "https://vimeo.com/search?q={{{s}}}&sort=date"},
+		{"c": "Online Services", "d": "duckduckgo.com", "s": "DuckDuckGo Images", "sc": "Search", "t": "i", "u": "/?q={{{s}}}&iax=images&ia=images"},
+		{"c": "Online Services", "d": "duckduckgo.com", "s": "DuckDuckGo", "sc": "Search", "t": "ddg", "u": "/?q={{{s}}}"}
+	]`
+
+	bangs, err := ParseDuckDuckGoBangs(strings.NewReader(bangJS))
+	if err != nil {
+		t.Fatalf("ParseDuckDuckGoBangs() error = %v", err)
+	}
+	if len(bangs) != 4 {
+		t.Fatalf("ParseDuckDuckGoBangs() returned %d bangs, want 4", len(bangs))
+	}
+
+	tests := []struct {
+		bang    DuckDuckGoBang
+		wantURL string
+		wantErr bool
+	}{
+		{bangs[0], "https://www.youtube.com/results?search_query=", false},
+		{bangs[1], "", true},
+		{bangs[2], "", true},
+		{bangs[3], "https://duckduckgo.com/?q=", false},
+	}
+	for _, tt := range tests {
+		engine, err := tt.bang.Engine()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Engine() for !%s error = %v, wantErr %v", tt.bang.Trigger, err, tt.wantErr)
+			continue
+		}
+		if engine.URL != tt.wantURL {
+			t.Errorf("Engine() for !%s URL = %q, want %q", tt.bang.Trigger, engine.URL, tt.wantURL)
+		}
+		if !tt.wantErr && (engine.Name != tt.bang.Name || engine.Encoding.Delimiter != "%20") {
+			t.Errorf("Engine() for !%s = %+v", tt.bang.Trigger, engine)
+		}
+	}
+
+	if !bangs[0].InCategory([]string{"video"}) || !bangs[0].InCategory([]string{"MULTIMEDIA"}) || !bangs[0].InCategory(nil) {
+		t.Error("InCategory() should match the category, the subcategory, or an empty filter")
+	}
+	if bangs[0].InCategory([]string{"Tech"}) {
+		t.Error("InCategory() matched an unrelated category")
+	}
+
+	if _, err := ParseDuckDuckGoBangs(strings.NewReader(`{"not": "a list"}`)); err == nil {
+		t.Error("ParseDuckDuckGoBangs() error = nil, want error for a non-array document")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package hunt
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts the opener in a new process group so that a Ctrl-C
+// aimed at hunt does not also kill the browser it launched
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
@@ -0,0 +1,23 @@
+// Package hunt builds search URLs for many search engines at once and opens them in a browser.
+//
+// Engines are grouped into categories and loaded from a search_engines.json file
+// with LoadConfig. Users pick engines by number or name (ParseSelections), each
+// engine turns a query into a URL (BuildSearchURL), and an Opener hands the URLs
+// to the system browser.
+//
+//	config, err := hunt.LoadConfig(hunt.WithFiles("search_engines.json"))
+//	if err != nil {
+//		return err
+//	}
+//	engines := config.GetEnginesByCategory("search")
+//	indices, err := hunt.ParseSelections(os.Stderr, []string{"bing", "3"}, engines)
+//	if err != nil {
+//		return err
+//	}
+//	for _, i := range indices {
+//		fmt.Println(hunt.BuildSearchURL(engines[i], "machine learning"))
+//	}
+//
+// The package never writes to os.Stdout or os.Stderr itself: functions that report
+// progress or warnings take an io.Writer. The hunt command lives in cmd/hunt.
+package hunt
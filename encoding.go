@@ -0,0 +1,132 @@
+package hunt
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Escape modes for Encoding.Escape
+const (
+	EscapeQuery = "query" // url.QueryEscape: the query lands in the query string (the default)
+	EscapePath  = "path"  // url.PathEscape: the query is a path segment
+)
+
+// Case transforms for Encoding.Case
+const (
+	CaseLower = "lower"
+	CaseUpper = "upper"
+)
+
+// Encoding describes how an engine expects the search term in its URL
+type Encoding struct {
+	Escape    string `json:"escape,omitempty"`    // EscapeQuery (default) or EscapePath
+	Case      string `json:"case,omitempty"`      // CaseLower or CaseUpper; unchanged when empty
+	Delimiter string `json:"delimiter,omitempty"` // Replaces spaces; "+" for query, "%20" for path by default
+	Charset   string `json:"charset,omitempty"`   // Legacy charset such as "Shift_JIS"; UTF-8 when empty
+}
+
+// Validate checks the escape mode, case transform and charset
+func (e Encoding) Validate() error {
+	switch e.Escape {
+	case "", EscapeQuery, EscapePath:
+	default:
+		return fmt.Errorf("invalid escape %q (must be %q or %q)", e.Escape, EscapeQuery, EscapePath)
+	}
+	switch e.Case {
+	case "", CaseLower, CaseUpper:
+	default:
+		return fmt.Errorf("invalid case %q (must be %q or %q)", e.Case, CaseLower, CaseUpper)
+	}
+	if _, err := e.charset(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// withDefaults fills in the delimiter for the escape mode
+func (e Encoding) withDefaults() Encoding {
+	if e.Delimiter == "" {
+		e.Delimiter = "+"
+		if e.Escape == EscapePath {
+			e.Delimiter = "%20"
+		}
+	}
+	return e
+}
+
+// charset returns the legacy charset encoder, or nil for UTF-8
+func (e Encoding) charset() (encoding.Encoding, error) {
+	if e.Charset == "" {
+		return nil, nil
+	}
+	enc, err := htmlindex.Get(e.Charset)
+	if err != nil {
+		return nil, fmt.Errorf("unknown charset %q", e.Charset)
+	}
+	if name, _ := htmlindex.Name(enc); name == "utf-8" {
+		return nil, nil
+	}
+	return enc, nil
+}
+
+// Encode encodes a search term for the engine's URL
+// Characters the charset can't represent are sent as HTML numeric character references,
+// as browsers do when submitting forms in legacy charsets.
+func (e Encoding) Encode(searchTerm string) string {
+	e = e.withDefaults()
+
+	searchTerm = e.applyCase(searchTerm)
+
+	if enc, err := e.charset(); err == nil && enc != nil {
+		if converted, err := encoding.HTMLEscapeUnsupported(enc.NewEncoder()).String(searchTerm); err == nil {
+			searchTerm = converted
+		}
+	}
+
+	if e.Escape == EscapePath {
+		// url.PathEscape encodes spaces as %20 and a literal "%" as %25, so only spaces match
+		encoded := url.PathEscape(searchTerm)
+		if e.Delimiter != "%20" {
+			encoded = strings.ReplaceAll(encoded, "%20", e.Delimiter)
+		}
+		return encoded
+	}
+	return URLEncode(searchTerm, e.Delimiter)
+}
+
+// applyCase applies the case transform to searchTerm
+func (e Encoding) applyCase(searchTerm string) string {
+	switch e.Case {
+	case CaseLower:
+		return strings.ToLower(searchTerm)
+	case CaseUpper:
+		return strings.ToUpper(searchTerm)
+	}
+	return searchTerm
+}
+
+// UnmarshalJSON reads an engine, mapping the legacy space_delimiter field onto Encoding
+func (e *SearchEngine) UnmarshalJSON(data []byte) error {
+	type plainEngine SearchEngine // Without this method, to avoid recursion
+	var raw struct {
+		plainEngine
+		SpaceDelimiter string `json:"space_delimiter"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = SearchEngine(raw.plainEngine)
+
+	if raw.SpaceDelimiter != "" {
+		if e.Encoding.Delimiter != "" && e.Encoding.Delimiter != raw.SpaceDelimiter {
+			return fmt.Errorf("engine %q sets both space_delimiter and encoding.delimiter", e.Name)
+		}
+		e.Encoding.Delimiter = raw.SpaceDelimiter
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package hunt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncoding_Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding Encoding
+		term     string
+		want     string
+	}{
+		{name: "query default", encoding: Encoding{}, term: "a b/c+d", want: "a+b%2Fc%2Bd"},
+		{name: "query with %20", encoding: Encoding{Delimiter: "%20"}, term: "a b+c", want: "a%20b%2Bc"},
+		{name: "path default", encoding: Encoding{Escape: EscapePath}, term: "a b/c+d", want: "a%20b%2Fc+d"},
+		{name: "path literal %20", encoding: Encoding{Escape: EscapePath, Delimiter: "_"}, term: "100%20 off", want: "100%2520_off"},
+		{name: "slug", encoding: Encoding{Escape: EscapePath, Case: CaseLower, Delimiter: "-"}, term: "Rust Async Book", want: "rust-async-book"},
+		{name: "upper", encoding: Encoding{Case: CaseUpper}, term: "aapl stock", want: "AAPL+STOCK"},
+		{name: "Shift_JIS", encoding: Encoding{Charset: "Shift_JIS"}, term: "日本 語", want: "%93%FA%96%7B+%8C%EA"},
+		{name: "ISO-8859-1", encoding: Encoding{Charset: "ISO-8859-1"}, term: "café", want: "caf%E9"},
+		{name: "unrepresentable character", encoding: Encoding{Charset: "ISO-8859-1"}, term: "日", want: "%26%2326085%3B"},
+		{name: "explicit UTF-8", encoding: Encoding{Charset: "utf-8"}, term: "café", want: "caf%C3%A9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.encoding.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := tt.encoding.Encode(tt.term); got != tt.want {
+				t.Errorf("Encode(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoding_Validate(t *testing.T) {
+	tests := []struct {
+		encoding Encoding
+		wantErr  string
+	}{
+		{Encoding{Escape: "fragment"}, "invalid escape"},
+		{Encoding{Case: "title"}, "invalid case"},
+		{Encoding{Charset: "x-klingon"}, "unknown charset"},
+	}
+	for _, tt := range tests {
+		if err := tt.encoding.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Validate(%+v) = %v, want an error containing %q", tt.encoding, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadConfig_Encoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  string
+		want    Encoding
+		wantErr string
+	}{
+		{
+			name:   "legacy space_delimiter",
+			engine: `{"name": "A", "url": "https://a.example/?q=", "space_delimiter": "%20"}`,
+			want:   Encoding{Delimiter: "%20"},
+		},
+		{
+			name:   "encoding block",
+			engine: `{"name": "A", "url": "https://a.example/wiki/", "encoding": {"escape": "path", "case": "lower", "delimiter": "_"}}`,
+			want:   Encoding{Escape: EscapePath, Case: CaseLower, Delimiter: "_"},
+		},
+		{
+			name:   "path escaping defaults to %20",
+			engine: `{"name": "A", "url": "https://a.example/wiki/", "encoding": {"escape": "path", "charset": "Shift_JIS"}}`,
+			want:   Encoding{Escape: EscapePath, Delimiter: "%20", Charset: "Shift_JIS"},
+		},
+		{
+			name:    "conflicting delimiters",
+			engine:  `{"name": "A", "url": "https://a.example/?q=", "space_delimiter": "+", "encoding": {"delimiter": "%20"}}`,
+			wantErr: "both space_delimiter and encoding.delimiter",
+		},
+		{
+			name:    "unknown charset",
+			engine:  `{"name": "A", "url": "https://a.example/?q=", "encoding": {"charset": "EBCDIC-XX"}}`,
+			wantErr: `engine "A" in category "search": unknown charset`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := LoadConfig(WithBytes([]byte(`{"search": [` + tt.engine + `]}`)))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if got := config.Categories["search"][0].Encoding; got != tt.want {
+				t.Errorf("Encoding = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package hunt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// engineObject is an engine's JSON object, kept raw until "extends" is resolved so that
+// an engine overrides only the fields it actually sets
+type engineObject map[string]json.RawMessage
+
+// name returns the engine's "name" field, or "" if it has none
+func (o engineObject) name() string {
+	var name string
+	json.Unmarshal(o["name"], &name)
+	return name
+}
+
+// engineRef identifies an engine as category:name
+type engineRef struct {
+	category string
+	index    int
+	name     string
+}
+
+func (r engineRef) String() string {
+	return r.category + ":" + r.name
+}
+
+// resolveEngines decodes every engine with the engines it extends merged in
+func resolveEngines(categories map[string][]engineObject) (map[string][]SearchEngine, error) {
+	resolver := &engineResolver{categories: categories, resolved: make(map[engineRef]engineObject)}
+	engines := make(map[string][]SearchEngine, len(categories))
+	for category, objects := range categories {
+		resolvedEngines := make([]SearchEngine, len(objects))
+		for i, object := range objects {
+			merged, err := resolver.resolve(engineRef{category: category, index: i, name: object.name()}, nil)
+			if err != nil {
+				return nil, err
+			}
+			data, err := json.Marshal(merged)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(data, &resolvedEngines[i]); err != nil {
+				return nil, fmt.Errorf("engine %q in category %q: %w", object.name(), category, err)
+			}
+		}
+		engines[category] = resolvedEngines
+	}
+	return engines, nil
+}
+
+// engineResolver resolves "extends" chains across the merged categories
+type engineResolver struct {
+	categories map[string][]engineObject
+	resolved   map[engineRef]engineObject
+}
+
+// findEngine looks up a "category:name" reference (the name is case-insensitive)
+func (r *engineResolver) findEngine(target string) (engineRef, bool) {
+	category, name, ok := strings.Cut(target, ":")
+	if !ok {
+		return engineRef{}, false
+	}
+	for i, object := range r.categories[category] {
+		if objectName := object.name(); strings.EqualFold(objectName, name) {
+			return engineRef{category: category, index: i, name: objectName}, true
+		}
+	}
+	return engineRef{}, false
+}
+
+// resolve returns the engine's object with everything it extends merged in
+// chain holds the engines that led here, to detect cycles and explain errors.
+func (r *engineResolver) resolve(ref engineRef, chain []engineRef) (engineObject, error) {
+	if object, ok := r.resolved[ref]; ok {
+		return object, nil
+	}
+	chain = append(chain, ref)
+	for _, seen := range chain[:len(chain)-1] {
+		if seen == ref {
+			return nil, fmt.Errorf("extends cycle: %s", formatChain(chain))
+		}
+	}
+
+	object, err := withEncodingDelimiter(r.categories[ref.category][ref.index])
+	if err != nil {
+		return nil, fmt.Errorf("engine %q in category %q: %w", ref.name, ref.category, err)
+	}
+	rawParent, ok := object["extends"]
+	if !ok {
+		r.resolved[ref] = object
+		return object, nil
+	}
+
+	var target string
+	if err := json.Unmarshal(rawParent, &target); err != nil || !strings.Contains(target, ":") {
+		return nil, fmt.Errorf("engine %q in category %q: extends must be a \"category:name\" string", ref.name, ref.category)
+	}
+	parentRef, ok := r.findEngine(target)
+	if !ok {
+		return nil, fmt.Errorf("extends %q: no such engine (chain: %s -> %s)", target, formatChain(chain), target)
+	}
+	parent, err := r.resolve(parentRef, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergeEngineObjects(parent, object)
+	r.resolved[ref] = merged
+	return merged, nil
+}
+
+// mergeEngineObjects overlays child's fields onto parent's
+// The encoding block is merged field by field; every other field except name and extends
+// is inherited unless the child sets it.
+func mergeEngineObjects(parent, child engineObject) engineObject {
+	merged := make(engineObject, len(parent)+len(child))
+	for key, value := range parent {
+		if key != "name" && key != "extends" {
+			merged[key] = value
+		}
+	}
+	for key, value := range child {
+		if key == "encoding" {
+			if combined, err := mergeJSONObjects(parent[key], value); err == nil {
+				value = combined
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// mergeJSONObjects overlays the keys of the JSON object child onto parent
+func mergeJSONObjects(parent, child json.RawMessage) (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if parent != nil {
+		if err := json.Unmarshal(parent, &fields); err != nil {
+			return nil, err
+		}
+	}
+	var childFields map[string]json.RawMessage
+	if err := json.Unmarshal(child, &childFields); err != nil {
+		return nil, err
+	}
+	for key, value := range childFields {
+		fields[key] = value
+	}
+	return json.Marshal(fields)
+}
+
+// withEncodingDelimiter moves a legacy space_delimiter field into the encoding block, so a
+// child's space_delimiter overrides its parent's encoding.delimiter and vice versa
+func withEncodingDelimiter(object engineObject) (engineObject, error) {
+	rawDelimiter, ok := object["space_delimiter"]
+	if !ok {
+		return object, nil
+	}
+	delimiter, err := json.Marshal(map[string]json.RawMessage{"delimiter": rawDelimiter})
+	if err != nil {
+		return nil, err
+	}
+	encoding := json.RawMessage(delimiter)
+	if existing, ok := object["encoding"]; ok {
+		if encoding, err = mergeJSONObjects(existing, encoding); err != nil {
+			return nil, err
+		}
+	}
+
+	moved := make(engineObject, len(object))
+	for key, value := range object {
+		moved[key] = value
+	}
+	delete(moved, "space_delimiter")
+	moved["encoding"] = encoding
+	return moved, nil
+}
+
+// formatChain describes an extends chain, e.g. "images:Google Images -> search:Google"
+func formatChain(chain []engineRef) string {
+	parts := make([]string, len(chain))
+	for i, ref := range chain {
+		parts[i] = ref.String()
+	}
+	return strings.Join(parts, " -> ")
+}
@@ -0,0 +1,98 @@
+package hunt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig_Extends(t *testing.T) {
+	config, err := parseConfig([]byte(`{
+		"search": [
+			{"name": "Google", "url": "https://www.google.com/search?q=", "private": true,
+			 "encoding": {"case": "lower", "delimiter": "%20"}, "query_transform": [{"op": "suffix", "value": "-ai"}]}
+		],
+		"images": [
+			{"name": "Google Images", "extends": "search:google", "url": "https://www.google.com/search?tbm=isch&q=", "space_delimiter": "+"},
+			{"name": "Google Images UK", "extends": "images:Google Images", "url": "https://www.google.co.uk/search?tbm=isch&q=", "private": false}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		category string
+		want     SearchEngine
+	}{
+		{"images", SearchEngine{
+			Name:           "Google Images",
+			URL:            "https://www.google.com/search?tbm=isch&q=",
+			Encoding:       Encoding{Case: CaseLower, Delimiter: "+"},
+			QueryTransform: config.Categories["search"][0].QueryTransform,
+			Private:        true,
+			Extends:        "search:google",
+		}},
+		{"images", SearchEngine{
+			Name:           "Google Images UK",
+			URL:            "https://www.google.co.uk/search?tbm=isch&q=",
+			Encoding:       Encoding{Case: CaseLower, Delimiter: "+"},
+			QueryTransform: config.Categories["search"][0].QueryTransform,
+			Extends:        "images:Google Images",
+		}},
+	}
+
+	for i, tt := range tests {
+		if got := config.Categories[tt.category][i]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("engine %d = %+v, want %+v", i, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_ExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			"missing parent",
+			`{"images": [{"name": "Google Images", "extends": "search:Gogle", "url": "https://www.google.com/search?tbm=isch&q="}]}`,
+			`extends "search:Gogle": no such engine (chain: images:Google Images -> search:Gogle)`,
+		},
+		{
+			"missing parent in chain",
+			`{"search": [{"name": "A", "extends": "search:B"}, {"name": "B", "extends": "shop:C"}]}`,
+			`(chain: search:A -> search:B -> shop:C)`,
+		},
+		{
+			"cycle",
+			`{"search": [{"name": "A", "extends": "search:B"}, {"name": "B", "extends": "search:C"}, {"name": "C", "extends": "search:A"}]}`,
+			"extends cycle: search:A -> search:B -> search:C -> search:A",
+		},
+		{
+			"self reference",
+			`{"search": [{"name": "A", "url": "https://a.example/?q=", "extends": "search:a"}]}`,
+			"extends cycle: search:A -> search:A",
+		},
+		{
+			"not category:name",
+			`{"search": [{"name": "A", "url": "https://a.example/?q=", "extends": "Google"}]}`,
+			`extends must be a "category:name" string`,
+		},
+		{
+			"child without URL or parent URL",
+			`{"search": [{"name": "A"}, {"name": "B", "extends": "search:A"}]}`,
+			"has no URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseConfig() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package hunt
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Sort orders for --sort
+const (
+	SortPriceAsc  = "price-asc"
+	SortPriceDesc = "price-desc"
+	SortNewest    = "newest"
+	SortRelevance = "relevance"
+)
+
+// SortOrders lists the --sort orders
+var SortOrders = []string{SortPriceAsc, SortPriceDesc, SortNewest, SortRelevance}
+
+// Item conditions for --condition
+const (
+	ConditionNew         = "new"
+	ConditionUsed        = "used"
+	ConditionRefurbished = "refurbished"
+)
+
+// Conditions lists the --condition values
+var Conditions = []string{ConditionNew, ConditionUsed, ConditionRefurbished}
+
+// ParseSortOrder validates a --sort order (case-insensitive) and returns its canonical form
+func ParseSortOrder(order string) (string, error) {
+	return parseChoice("sort order", order, SortOrders)
+}
+
+// ParseCondition validates a --condition (case-insensitive) and returns its canonical form
+func ParseCondition(condition string) (string, error) {
+	return parseChoice("condition", condition, Conditions)
+}
+
+// parseChoice returns the choice matching value case-insensitively
+func parseChoice(what, value string, choices []string) (string, error) {
+	for _, choice := range choices {
+		if strings.EqualFold(value, choice) {
+			return choice, nil
+		}
+	}
+	return "", fmt.Errorf("unknown %s %q (want %s)", what, value, strings.Join(choices, ", "))
+}
+
+// ShopFacets maps the shopping filters onto an engine's URL parameters
+type ShopFacets struct {
+	Sort      map[string]map[string]string `json:"sort,omitempty"`      // --sort order to parameters, e.g. "price-asc": {"_sop": "15"}
+	Price     map[string]string            `json:"price,omitempty"`     // Parameters for --min-price/--max-price; values may use {min}, {max}, {min_cents} and {max_cents}
+	Condition map[string]map[string]string `json:"condition,omitempty"` // --condition to parameters, e.g. "used": {"LH_ItemCondition": "3000"}
+}
+
+// Validate checks the sort orders and conditions
+func (f ShopFacets) Validate() error {
+	for order, params := range f.Sort {
+		if canonical, err := ParseSortOrder(order); err != nil || canonical != order {
+			return fmt.Errorf("facets: unknown sort order %q (want %s)", order, strings.Join(SortOrders, ", "))
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("facets: sort order %q has no parameters", order)
+		}
+	}
+	for condition, params := range f.Condition {
+		if canonical, err := ParseCondition(condition); err != nil || canonical != condition {
+			return fmt.Errorf("facets: unknown condition %q (want %s)", condition, strings.Join(Conditions, ", "))
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("facets: condition %q has no parameters", condition)
+		}
+	}
+	return nil
+}
+
+// sortParams returns the parameters for order, reporting false if the engine has none
+func (f ShopFacets) sortParams(order string) (url.Values, bool) {
+	params, ok := f.Sort[order]
+	if !ok {
+		return nil, false
+	}
+	return toValues(params), true
+}
+
+// conditionParams returns the parameters for condition, reporting false if the engine has none
+func (f ShopFacets) conditionParams(condition string) (url.Values, bool) {
+	params, ok := f.Condition[condition]
+	if !ok {
+		return nil, false
+	}
+	return toValues(params), true
+}
+
+// priceParams returns the parameters for a price range; 0 leaves that end open
+// Parameters that come out empty, such as a maximum when only --min-price is given, are left out.
+// Reports false if the engine can't filter by price.
+func (f ShopFacets) priceParams(min, max float64) (url.Values, bool) {
+	if len(f.Price) == 0 {
+		return nil, false
+	}
+	replacer := strings.NewReplacer(
+		"{min}", formatPrice(min),
+		"{max}", formatPrice(max),
+		"{min_cents}", formatCents(min),
+		"{max_cents}", formatCents(max),
+	)
+	params := url.Values{}
+	for name, value := range f.Price {
+		if value = replacer.Replace(value); value != "" {
+			params.Set(name, value)
+		}
+	}
+	return params, true
+}
+
+// formatPrice formats a price without trailing zeros, or "" when it is unset
+func formatPrice(price float64) string {
+	if price == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+// formatCents formats a price in cents, or "" when it is unset
+func formatCents(price float64) string {
+	if price == 0 {
+		return ""
+	}
+	return strconv.FormatInt(int64(math.Round(price*100)), 10)
+}
@@ -0,0 +1,161 @@
+package hunt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// mozLz4Magic starts every mozLz4 file, such as Firefox's search.json.mozlz4
+const mozLz4Magic = "mozLz40\x00"
+
+// FirefoxSearchFile is the name of the file holding a Firefox profile's search engines
+const FirefoxSearchFile = "search.json.mozlz4"
+
+// FirefoxEngine is one engine from a Firefox profile's search.json
+type FirefoxEngine struct {
+	Name          string       `json:"_name"`
+	LoadPath      string       `json:"_loadPath"`      // Where the engine came from, e.g., "[app]google" or "[user]"
+	IsAppProvided bool         `json:"_isAppProvided"` // Shipped with Firefox rather than added by the user
+	QueryCharset  string       `json:"queryCharset"`
+	URLs          []FirefoxURL `json:"_urls"`
+}
+
+// FirefoxURL is one of an engine's URL templates (results, suggestions, ...)
+type FirefoxURL struct {
+	Type     string         `json:"type"`
+	Method   string         `json:"method"`
+	Template string         `json:"template"`
+	Params   []FirefoxParam `json:"params"`
+}
+
+// FirefoxParam is a query parameter Firefox appends to a URL template
+// Parameters with a purpose are only sent for that kind of search (e.g., "contextmenu").
+type FirefoxParam struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Purpose string `json:"purpose"`
+}
+
+// DecodeMozLz4 decompresses a mozLz4 file: the magic, the decompressed size and an LZ4 block
+func DecodeMozLz4(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(mozLz4Magic)) {
+		return nil, errors.New("not a mozLz4 file")
+	}
+	data = data[len(mozLz4Magic):]
+	if len(data) < 4 {
+		return nil, errors.New("truncated mozLz4 header")
+	}
+	size := binary.LittleEndian.Uint32(data)
+	return decodeLz4Block(data[4:], int(size))
+}
+
+// decodeLz4Block decompresses a raw LZ4 block that decompresses to exactly size bytes
+func decodeLz4Block(src []byte, size int) ([]byte, error) {
+	errCorrupt := errors.New("corrupt LZ4 block")
+	// Each input byte expands to at most 255 output bytes, so a larger size is a corrupt header
+	if size > len(src)*255 {
+		return nil, fmt.Errorf("LZ4 block of %d bytes can't decompress to %d bytes", len(src), size)
+	}
+	dst := make([]byte, 0, size)
+
+	// readLength extends a 4-bit length with 255-valued continuation bytes
+	i := 0
+	readLength := func(length int) (int, bool) {
+		if length != 15 {
+			return length, true
+		}
+		for {
+			if i >= len(src) {
+				return 0, false
+			}
+			b := src[i]
+			i++
+			length += int(b)
+			if b != 255 {
+				return length, true
+			}
+		}
+	}
+
+	for i < len(src) {
+		token := src[i]
+		i++
+
+		literals, ok := readLength(int(token >> 4))
+		if !ok || i+literals > len(src) || len(dst)+literals > size {
+			return nil, errCorrupt
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		if i == len(src) {
+			break // The last sequence has literals only
+		}
+
+		if i+2 > len(src) {
+			return nil, errCorrupt
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		match, ok := readLength(int(token & 15))
+		if !ok || offset == 0 || offset > len(dst) || len(dst)+match+4 > size {
+			return nil, errCorrupt
+		}
+		// Byte by byte, since the match may overlap the bytes it is copying
+		start := len(dst) - offset
+		for j := 0; j < match+4; j++ {
+			dst = append(dst, dst[start+j])
+		}
+	}
+
+	if len(dst) != size {
+		return nil, errCorrupt
+	}
+	return dst, nil
+}
+
+// ParseFirefoxSearchEngines reads a profile's search.json.mozlz4 (or its decompressed JSON)
+func ParseFirefoxSearchEngines(r io.Reader) ([]FirefoxEngine, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte(mozLz4Magic)) {
+		if data, err = DecodeMozLz4(data); err != nil {
+			return nil, fmt.Errorf("failed to decompress Firefox search engines: %w", err)
+		}
+	}
+
+	var file struct {
+		Engines []FirefoxEngine `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse Firefox search engines: %w", err)
+	}
+	return file.Engines, nil
+}
+
+// BuiltIn reports whether the engine ships with Firefox rather than being added by the user
+func (e FirefoxEngine) BuiltIn() bool {
+	return e.IsAppProvided || strings.HasPrefix(e.LoadPath, "[app]") || strings.HasPrefix(e.LoadPath, "jar:")
+}
+
+// Engine converts the engine's results URL into a SearchEngine
+// Firefox templates use OpenSearch syntax, so the same restrictions apply.
+func (e FirefoxEngine) Engine() (SearchEngine, error) {
+	desc := OpenSearchDescription{ShortName: strings.TrimSpace(e.Name), InputEncoding: e.QueryCharset}
+	for _, u := range e.URLs {
+		osURL := OpenSearchURL{Type: u.Type, Method: u.Method, Template: u.Template}
+		for _, param := range u.Params {
+			if param.Purpose == "" {
+				osURL.Params = append(osURL.Params, OpenSearchParam{Name: param.Name, Value: param.Value})
+			}
+		}
+		desc.URLs = append(desc.URLs, osURL)
+	}
+	return desc.Engine()
+}
@@ -0,0 +1,83 @@
+package hunt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// firefoxTestSearchFile is a search.json.mozlz4 (compressed by the lz4 tool) holding a
+// built-in Google engine, a custom Go Packages engine and a custom POST engine
+const firefoxTestSearchFile = "bW96THo0MADiAQAA8UR7InZlcnNpb24iOjYsImVuZ2luZXMiOlt7Il9uYW1lIjoiR29vZ2xlIiwiX2lzQXBwUHJvdmlkZWQiOnRydWUsIl9sb2FkUGF0aCI6IlthcHBdZzAA8ARAc2VhcmNoLm1vemlsbGEub3JnQwAydXJsXgBwdGVtcGxhdGEAwmh0dHBzOi8vd3d3Lj0AUi5jb20vQQBCP3E9ewoA4FRlcm1zfSIsInBhcmFtRgBYXX1dfSypAJAgUGFja2FnZXNrAAiYAFB1c2VyXRUAD4AACbJwa2cuZ28uZGV2L3IAD3wAGGBQb3N0ZXJiAA93AB8BOwBpLmV4YW1w+wD4ASIsIm1ldGhvZCI6IlBPU1T7ACN7Ip4BynEiLCJ2YWx1ZSI6IikBgH1dfV19XX0K"
+
+func TestParseFirefoxSearchEngines(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(firefoxTestSearchFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engines, err := ParseFirefoxSearchEngines(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseFirefoxSearchEngines() error = %v", err)
+	}
+	if len(engines) != 3 {
+		t.Fatalf("got %d engines, want 3", len(engines))
+	}
+
+	tests := []struct {
+		engine      FirefoxEngine
+		wantBuiltIn bool
+		want        SearchEngine
+		wantErr     string
+	}{
+		{engine: engines[0], wantBuiltIn: true, want: SearchEngine{Name: "Google", URL: "https://www.google.com/search?q=", Encoding: Encoding{Delimiter: "+"}}},
+		{engine: engines[1], want: SearchEngine{Name: "Go Packages", URL: "https://pkg.go.dev/search?q=", Encoding: Encoding{Delimiter: "+"}}},
+		{engine: engines[2], want: SearchEngine{Name: "Poster", URL: "https://poster.example.com/search", Method: MethodPost, Form: []FormField{{Name: "q", Value: "{query}"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine.Name, func(t *testing.T) {
+			if got := tt.engine.BuiltIn(); got != tt.wantBuiltIn {
+				t.Errorf("BuiltIn() = %v, want %v", got, tt.wantBuiltIn)
+			}
+			got, err := tt.engine.Engine()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Engine() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Engine() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMozLz4(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		// Token 0x35: 3 literals, then a match of 5+4 bytes at offset 3 overlapping itself
+		{name: "overlapping match", data: mozLz4Magic + "\x0c\x00\x00\x00" + "\x35abc\x03\x00", want: "abcabcabcabc"},
+		{name: "literals only", data: mozLz4Magic + "\x02\x00\x00\x00" + "\x20{}", want: "{}"},
+		{name: "wrong magic", data: "mozLz41\x00\x02\x00\x00\x00\x20{}", wantErr: true},
+		{name: "size mismatch", data: mozLz4Magic + "\x05\x00\x00\x00" + "\x20{}", wantErr: true},
+		{name: "size beyond the input", data: mozLz4Magic + "\xff\xff\xff\x7f" + "\x20{}", wantErr: true},
+		{name: "offset before start", data: mozLz4Magic + "\x0c\x00\x00\x00" + "\x35abc\x09\x00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeMozLz4([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeMozLz4() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DecodeMozLz4() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
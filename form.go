@@ -0,0 +1,153 @@
+package hunt
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// FormQueryPlaceholder marks where the query goes in a POST engine's form field values
+const FormQueryPlaceholder = "{query}"
+
+// HTTP methods for SearchEngine.Method
+const (
+	MethodGet  = "GET"
+	MethodPost = "POST"
+)
+
+// FormField is one field of a POST engine's form
+type FormField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SearchRequest is how a search reaches an engine: a URL to open, or a form to POST
+type SearchRequest struct {
+	Method  string      // MethodGet or MethodPost
+	URL     string      // The search URL for GET, the form action for POST
+	Fields  []FormField // POST fields, with the query filled in
+	Charset string      // Charset the form is submitted in; UTF-8 when empty
+}
+
+// validateMethod checks the engine's method, form fields and URL length limit
+func (e SearchEngine) validateMethod() error {
+	switch strings.ToUpper(e.Method) {
+	case "", MethodGet:
+		if len(e.Form) > 0 {
+			return fmt.Errorf("form fields need \"method\": %q", MethodPost)
+		}
+		if e.MaxURLLength > 0 {
+			if _, _, ok := splitQueryPrefix(e.URL); !ok {
+				return fmt.Errorf("max_url_length needs a URL ending in a query parameter (e.g., ?q=) so long queries can be POSTed")
+			}
+		}
+	case MethodPost:
+		for _, field := range e.Form {
+			if strings.Contains(field.Value, FormQueryPlaceholder) {
+				return nil
+			}
+		}
+		return fmt.Errorf("POST engines need a form field containing %s", FormQueryPlaceholder)
+	default:
+		return fmt.Errorf("invalid method %q (must be %q or %q)", e.Method, MethodGet, MethodPost)
+	}
+	return nil
+}
+
+// splitQueryPrefix splits a URL prefix ending in a query parameter, such as
+// "https://example.com/search?lang=en&q=", into the form action and its fields.
+// The last field is the one the query fills in.
+func splitQueryPrefix(prefix string) (string, []FormField, bool) {
+	action, rawQuery, ok := strings.Cut(prefix, "?")
+	if !ok || !strings.HasSuffix(rawQuery, "=") {
+		return "", nil, false
+	}
+	var fields []FormField
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name, err1 := url.QueryUnescape(name)
+		value, err2 := url.QueryUnescape(value)
+		if err1 != nil || err2 != nil {
+			return "", nil, false
+		}
+		fields = append(fields, FormField{Name: name, Value: value})
+	}
+	return action, fields, true
+}
+
+// BuildSearchRequest builds the request that searches engine for searchTerm
+// POST engines get their form fields filled in. A GET URL longer than the engine's
+// MaxURLLength is sent as a POST form of its query parameters instead.
+func BuildSearchRequest(engine SearchEngine, searchTerm string) SearchRequest {
+	// Forms are submitted by the browser, which does its own escaping, so only the case applies
+	prepared := engine.PrepareQuery(searchTerm)
+	formTerm := Encoding{Case: engine.Encoding.Case}.applyCase(prepared.Query)
+
+	if strings.EqualFold(engine.Method, MethodPost) {
+		fields := make([]FormField, len(engine.Form))
+		for i, field := range engine.Form {
+			fields[i] = FormField{Name: field.Name, Value: strings.ReplaceAll(field.Value, FormQueryPlaceholder, formTerm)}
+		}
+		fields = append(fields, paramFields(prepared.Params)...)
+		return SearchRequest{Method: MethodPost, URL: engine.URL, Fields: fields, Charset: engine.Encoding.Charset}
+	}
+
+	searchURL := BuildSearchURL(engine, searchTerm)
+	if engine.MaxURLLength > 0 && len(searchURL) > engine.MaxURLLength {
+		if action, fields, ok := splitQueryPrefix(engine.URL); ok {
+			fields[len(fields)-1].Value += formTerm
+			fields = append(fields, paramFields(prepared.Params)...)
+			return SearchRequest{Method: MethodPost, URL: action, Fields: fields, Charset: engine.Encoding.Charset}
+		}
+	}
+	return SearchRequest{Method: MethodGet, URL: searchURL}
+}
+
+// WriteFormPage writes a page that submits req's form as soon as it loads
+// name labels the page and the fallback button shown when scripts are disabled.
+func WriteFormPage(w io.Writer, name string, req SearchRequest) error {
+	return formPageTemplate.Execute(w, struct {
+		Name string
+		SearchRequest
+	}{name, req})
+}
+
+// WriteFormPageFile writes the auto-submitting form page to a new file in the system temp
+// directory and returns its path, like WriteLandingPageFile
+func WriteFormPageFile(name string, req SearchRequest) (string, error) {
+	f, err := os.CreateTemp("", "hunt-form-*.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to create form page: %w", err)
+	}
+	defer f.Close()
+
+	if err := WriteFormPage(f, name, req); err != nil {
+		return "", fmt.Errorf("failed to write form page: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write form page: %w", err)
+	}
+	return f.Name(), nil
+}
+
+var formPageTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>hunt: {{.Name}}</title>
+</head>
+<body>
+<form method="post" action="{{.URL}}"{{if .Charset}} accept-charset="{{.Charset}}"{{end}}>
+{{range .Fields}}  <input type="hidden" name="{{.Name}}" value="{{.Value}}">
+{{end}}  <noscript><button type="submit">Search {{.Name}}</button></noscript>
+</form>
+<script>document.forms[0].submit();</script>
+</body>
+</html>
+`))
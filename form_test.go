@@ -0,0 +1,116 @@
+package hunt
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildSearchRequest(t *testing.T) {
+	catalog := SearchEngine{
+		Name:   "Catalog",
+		URL:    "https://catalog.example.edu/search",
+		Method: "post",
+		Form:   []FormField{{Name: "type", Value: "keyword"}, {Name: "terms", Value: "{query}"}},
+	}
+	short := SearchEngine{Name: "Short", URL: "https://short.example/s?lang=en&q=", MaxURLLength: 40}
+
+	tests := []struct {
+		name   string
+		engine SearchEngine
+		term   string
+		want   SearchRequest
+	}{
+		{
+			name:   "POST engine",
+			engine: catalog,
+			term:   "dune & sons",
+			want: SearchRequest{Method: MethodPost, URL: "https://catalog.example.edu/search", Fields: []FormField{
+				{Name: "type", Value: "keyword"},
+				{Name: "terms", Value: "dune & sons"},
+			}},
+		},
+		{
+			name:   "GET under the limit",
+			engine: short,
+			term:   "go",
+			want:   SearchRequest{Method: MethodGet, URL: "https://short.example/s?lang=en&q=go"},
+		},
+		{
+			name:   "GET over the limit",
+			engine: short,
+			term:   "a rather long query",
+			want: SearchRequest{Method: MethodPost, URL: "https://short.example/s", Fields: []FormField{
+				{Name: "lang", Value: "en"},
+				{Name: "q", Value: "a rather long query"},
+			}},
+		},
+		{
+			name:   "case and charset",
+			engine: SearchEngine{Name: "Legacy", URL: "https://legacy.example.jp/find", Method: MethodPost, Form: []FormField{{Name: "kw", Value: "{query}"}}, Encoding: Encoding{Case: CaseUpper, Charset: "Shift_JIS"}},
+			term:   "abc",
+			want:   SearchRequest{Method: MethodPost, URL: "https://legacy.example.jp/find", Fields: []FormField{{Name: "kw", Value: "ABC"}}, Charset: "Shift_JIS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildSearchRequest(tt.engine, tt.term); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildSearchRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Method(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  string
+		wantErr string
+	}{
+		{name: "POST", engine: `{"name": "A", "url": "https://a.example/", "method": "POST", "form": [{"name": "q", "value": "{query}"}]}`},
+		{name: "max URL length", engine: `{"name": "A", "url": "https://a.example/?q=", "max_url_length": 2000}`},
+		{name: "POST without the query", engine: `{"name": "A", "url": "https://a.example/", "method": "POST", "form": [{"name": "q", "value": "x"}]}`, wantErr: "need a form field containing {query}"},
+		{name: "form on a GET engine", engine: `{"name": "A", "url": "https://a.example/?q=", "form": [{"name": "q", "value": "{query}"}]}`, wantErr: "form fields need"},
+		{name: "max URL length on a path engine", engine: `{"name": "A", "url": "https://a.example/wiki/", "max_url_length": 2000}`, wantErr: "max_url_length needs"},
+		{name: "unknown method", engine: `{"name": "A", "url": "https://a.example/?q=", "method": "PUT"}`, wantErr: "invalid method"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(WithBytes([]byte(`{"search": [` + tt.engine + `]}`)))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("LoadConfig() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteFormPage(t *testing.T) {
+	req := SearchRequest{
+		Method:  MethodPost,
+		URL:     "https://catalog.example.edu/search",
+		Fields:  []FormField{{Name: "terms", Value: `"dune" <b>`}},
+		Charset: "Shift_JIS",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteFormPage(&buf, "Catalog", req); err != nil {
+		t.Fatalf("WriteFormPage() error = %v", err)
+	}
+	page := buf.String()
+
+	for _, want := range []string{
+		`<form method="post" action="https://catalog.example.edu/search" accept-charset="Shift_JIS">`,
+		`<input type="hidden" name="terms" value="&#34;dune&#34; &lt;b&gt;">`,
+		`document.forms[0].submit()`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("WriteFormPage() output missing %q:\n%s", want, page)
+		}
+	}
+}
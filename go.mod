@@ -1,3 +1,5 @@
 module github.com/aneely/hunt
 
 go 1.24.7
+
+require golang.org/x/text v0.30.0
@@ -0,0 +1,154 @@
+package hunt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ImportSkip records an imported entry that was not added, and why
+type ImportSkip struct {
+	Name   string
+	Reason string
+}
+
+// TemplateToPrefix converts a URL template into hunt's URL-prefix model
+// It succeeds only when placeholder occurs exactly once, at the very end of the template.
+func TemplateToPrefix(template, placeholder string) (string, bool) {
+	template = strings.TrimSpace(template)
+	if strings.Count(template, placeholder) != 1 || !strings.HasSuffix(template, placeholder) {
+		return "", false
+	}
+	prefix := strings.TrimSuffix(template, placeholder)
+	if prefix == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// MergeEngines returns the incoming engines that can be added to config without
+// duplicating an existing engine name (compared with NormalizeEngineName, across every
+// category) or URL. Duplicates within incoming are skipped too; the first one wins.
+func MergeEngines(config *Config, incoming []SearchEngine) ([]SearchEngine, []ImportSkip) {
+	names := make(map[string]string)
+	urls := make(map[string]string)
+	for _, category := range config.CategoryNames() {
+		for _, engine := range config.Categories[category] {
+			names[NormalizeEngineName(engine.Name)] = fmt.Sprintf("%s in %s", engine.Name, category)
+			urls[engine.URL] = fmt.Sprintf("%s in %s", engine.Name, category)
+		}
+	}
+
+	var added []SearchEngine
+	var skipped []ImportSkip
+	for _, engine := range incoming {
+		name := NormalizeEngineName(engine.Name)
+		if existing, ok := names[name]; ok {
+			skipped = append(skipped, ImportSkip{Name: engine.Name, Reason: "duplicate name (" + existing + ")"})
+			continue
+		}
+		if existing, ok := urls[engine.URL]; ok {
+			skipped = append(skipped, ImportSkip{Name: engine.Name, Reason: "duplicate URL (" + existing + ")"})
+			continue
+		}
+		names[name] = engine.Name + " (imported)"
+		urls[engine.URL] = engine.Name + " (imported)"
+		added = append(added, engine)
+	}
+	return added, skipped
+}
+
+// AppendEngines adds engines to the end of category in the search_engines.json document
+// data, creating the category if needed, and returns the new document. Top-level keys keep
+// their order and existing engines keep any fields hunt does not know about.
+func AppendEngines(data []byte, category string, engines []SearchEngine) ([]byte, error) {
+	keys, values, err := decodeOrderedObject(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []json.RawMessage
+	if raw, ok := values[category]; ok {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return nil, fmt.Errorf("failed to parse category %q: %w", category, err)
+		}
+	} else {
+		keys = append(keys, category)
+	}
+	for _, engine := range engines {
+		raw, err := marshalJSON(engine)
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, raw)
+	}
+	if values[category], err = marshalJSON(existing); err != nil {
+		return nil, err
+	}
+
+	return encodeOrderedObject(keys, values)
+}
+
+// decodeOrderedObject splits a JSON object into its keys, in document order, and raw values
+func decodeOrderedObject(data []byte) ([]string, map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("failed to parse JSON: expected an object")
+	}
+
+	var keys []string
+	values := make(map[string]json.RawMessage)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		key := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+	return keys, values, nil
+}
+
+// encodeOrderedObject writes keys and their values as a JSON object in the same
+// two-space indented layout as the bundled search_engines.json
+func encodeOrderedObject(keys []string, values map[string]json.RawMessage) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{\n")
+	for i, key := range keys {
+		name, err := marshalJSON(key)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("  ")
+		b.Write(name)
+		b.WriteString(": ")
+		if err := json.Indent(&b, values[key], "  ", "  "); err != nil {
+			return nil, err
+		}
+		if i < len(keys)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+// marshalJSON encodes v without escaping &, < and >, which are common in URLs
+func marshalJSON(v any) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
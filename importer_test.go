@@ -0,0 +1,129 @@
+package hunt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateToPrefix(t *testing.T) {
+	tests := []struct {
+		template string
+		want     string
+		wantOK   bool
+	}{
+		{"https://www.youtube.com/results?search_query={{{s}}}", "https://www.youtube.com/results?search_query=", true},
+		{"https://en.wikipedia.org/wiki/Special:Search/{{{s}}}", "https://en.wikipedia.org/wiki/Special:Search/", true},
+		{"https://vimeo.com/search?q={{{s}}}&sort=date", "", false},
+		{"https://example.com/{{{s}}}/{{{s}}}", "", false},
+		{"https://example.com/search", "", false},
+		{"{{{s}}}", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := TemplateToPrefix(tt.template, "{{{s}}}")
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("TemplateToPrefix(%q) = %q, %v, want %q, %v", tt.template, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestMergeEngines(t *testing.T) {
+	config := &Config{Categories: map[string][]SearchEngine{
+		"search":   {{Name: "Bing", URL: "https://www.bing.com/search?q="}},
+		"technews": {{Name: "Hacker News", URL: "https://hn.algolia.com/?q="}},
+	}}
+	incoming := []SearchEngine{
+		{Name: "hacker-news", URL: "https://news.ycombinator.com/?q="},
+		{Name: "Bing Copy", URL: "https://www.bing.com/search?q="},
+		{Name: "YouTube", URL: "https://www.youtube.com/results?search_query="},
+		{Name: "YouTube", URL: "https://m.youtube.com/results?search_query="},
+		{Name: "Vimeo", URL: "https://vimeo.com/search?q="},
+	}
+
+	added, skipped := MergeEngines(config, incoming)
+
+	var names []string
+	for _, engine := range added {
+		names = append(names, engine.Name)
+	}
+	if strings.Join(names, ",") != "YouTube,Vimeo" {
+		t.Errorf("MergeEngines() added %v, want [YouTube Vimeo]", names)
+	}
+
+	wantReasons := []string{"duplicate name (Hacker News in technews)", "duplicate URL (Bing in search)", "duplicate name (YouTube (imported))"}
+	if len(skipped) != len(wantReasons) {
+		t.Fatalf("MergeEngines() skipped %+v, want %d entries", skipped, len(wantReasons))
+	}
+	for i, want := range wantReasons {
+		if skipped[i].Reason != want {
+			t.Errorf("skipped[%d].Reason = %q, want %q", i, skipped[i].Reason, want)
+		}
+	}
+}
+
+func TestAppendEngines(t *testing.T) {
+	data := []byte(`{
+  "settings": {"open": {"strategy": "adaptive"}},
+  "search": [
+    {"name": "Bing", "url": "https://www.bing.com/search?q=", "space_delimiter": "+", "note": "kept"}
+  ],
+  "news": [
+    {"name": "NPR", "url": "https://www.npr.org/search?query="}
+  ]
+}`)
+
+	t.Run("existing category", func(t *testing.T) {
+		got, err := AppendEngines(data, "search", []SearchEngine{{Name: "Shop & Go", URL: "https://example.com/?a=1&q=", Encoding: Encoding{Delimiter: "%20"}}})
+		if err != nil {
+			t.Fatalf("AppendEngines() error = %v", err)
+		}
+		out := string(got)
+
+		// Key order is preserved and unknown fields survive
+		if strings.Index(out, `"settings"`) > strings.Index(out, `"search"`) || strings.Index(out, `"search"`) > strings.Index(out, `"news"`) {
+			t.Errorf("AppendEngines() reordered keys:\n%s", out)
+		}
+		if !strings.Contains(out, `"note": "kept"`) {
+			t.Errorf("AppendEngines() dropped an unknown field:\n%s", out)
+		}
+		// URLs are not HTML-escaped
+		if !strings.Contains(out, `"url": "https://example.com/?a=1&q="`) {
+			t.Errorf("AppendEngines() escaped the new URL:\n%s", out)
+		}
+
+		config, err := parseConfig(got)
+		if err != nil {
+			t.Fatalf("AppendEngines() produced an invalid config: %v\n%s", err, out)
+		}
+		engines := config.GetEnginesByCategory("search")
+		if len(engines) != 2 || engines[1].Name != "Shop & Go" {
+			t.Errorf("search engines = %+v, want Bing then Shop & Go", engines)
+		}
+		if config.Settings.Open.Strategy != "adaptive" {
+			t.Errorf("AppendEngines() lost settings: %+v", config.Settings)
+		}
+	})
+
+	t.Run("new category", func(t *testing.T) {
+		got, err := AppendEngines(data, "video", []SearchEngine{{Name: "Vimeo", URL: "https://vimeo.com/search?q="}})
+		if err != nil {
+			t.Fatalf("AppendEngines() error = %v", err)
+		}
+		config, err := parseConfig(got)
+		if err != nil {
+			t.Fatalf("AppendEngines() produced an invalid config: %v\n%s", err, got)
+		}
+		if engines := config.GetEnginesByCategory("video"); len(engines) != 1 {
+			t.Errorf("video engines = %+v, want Vimeo", engines)
+		}
+		if !strings.HasSuffix(strings.TrimSpace(string(got)), "]\n}") {
+			t.Errorf("AppendEngines() did not add the category last:\n%s", got)
+		}
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		if _, err := AppendEngines([]byte(`[]`), "search", nil); err == nil {
+			t.Error("AppendEngines() error = nil, want error for a non-object document")
+		}
+	})
+}
@@ -1,8 +1,7 @@
-package main
+package hunt
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -64,9 +63,9 @@ func TestIntegration_URLConstructionAndEncoding(t *testing.T) {
 // service selection parsing and URL building for multiple engines
 func TestIntegration_ServiceSelectionAndURLBuilding(t *testing.T) {
 	engines := []SearchEngine{
-		{Name: "Bing", URL: "https://www.bing.com/search?q=", SpaceDelimiter: "+"},
-		{Name: "Google", URL: "https://www.google.com/search?q=", SpaceDelimiter: "+"},
-		{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=", SpaceDelimiter: "+"},
+		{Name: "Bing", URL: "https://www.bing.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "Google", URL: "https://www.google.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=", Encoding: Encoding{Delimiter: "+"}},
 	}
 
 	tests := []struct {
@@ -109,7 +108,7 @@ func TestIntegration_ServiceSelectionAndURLBuilding(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Parse selections
-			indices, err := ParseSelections(tt.selections, engines)
+			indices, err := ParseSelections(nil, tt.selections, engines)
 			if err != nil {
 				t.Fatalf("ParseSelections() error = %v", err)
 			}
@@ -147,13 +146,13 @@ func TestIntegration_ServiceSelectionAndURLBuilding(t *testing.T) {
 // selections are properly removed and URLs are built correctly
 func TestIntegration_DuplicateRemovalAndURLBuilding(t *testing.T) {
 	engines := []SearchEngine{
-		{Name: "Bing", URL: "https://www.bing.com/search?q=", SpaceDelimiter: "+"},
-		{Name: "Google", URL: "https://www.google.com/search?q=", SpaceDelimiter: "+"},
+		{Name: "Bing", URL: "https://www.bing.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "Google", URL: "https://www.google.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
 	}
 
 	// Select same engine multiple times (by number and name)
 	selections := []string{"1", "Bing", "1"}
-	indices, err := ParseSelections(selections, engines)
+	indices, err := ParseSelections(nil, selections, engines)
 	if err != nil {
 		t.Fatalf("ParseSelections() error = %v", err)
 	}
@@ -179,7 +178,7 @@ func TestIntegration_DuplicateRemovalAndURLBuilding(t *testing.T) {
 // are properly encoded through the full pipeline
 func TestIntegration_SpecialCharactersInSearchTerm(t *testing.T) {
 	engines := []SearchEngine{
-		{Name: "Test", URL: "https://test.com/search?q=", SpaceDelimiter: "+"},
+		{Name: "Test", URL: "https://test.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
 	}
 
 	testCases := []struct {
@@ -223,14 +222,14 @@ func TestIntegration_SpecialCharactersInSearchTerm(t *testing.T) {
 // works correctly with multiple engines and builds all URLs
 func TestIntegration_AllSelectionWithMultipleEngines(t *testing.T) {
 	engines := []SearchEngine{
-		{Name: "Bing", URL: "https://www.bing.com/search?q=", SpaceDelimiter: "+"},
-		{Name: "Google", URL: "https://www.google.com/search?q=", SpaceDelimiter: "+"},
-		{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=", SpaceDelimiter: "+"},
-		{Name: "YouTube", URL: "https://www.youtube.com/results?search_query=", SpaceDelimiter: "+"},
+		{Name: "Bing", URL: "https://www.bing.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "Google", URL: "https://www.google.com/search?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "YouTube", URL: "https://www.youtube.com/results?search_query=", Encoding: Encoding{Delimiter: "+"}},
 	}
 
 	selections := []string{"all"}
-	indices, err := ParseSelections(selections, engines)
+	indices, err := ParseSelections(nil, selections, engines)
 	if err != nil {
 		t.Fatalf("ParseSelections() error = %v", err)
 	}
@@ -333,16 +332,16 @@ func TestIntegration_NewCategories(t *testing.T) {
 // TestIntegration_NewCategoriesServiceSelection tests service selection for new categories
 func TestIntegration_NewCategoriesServiceSelection(t *testing.T) {
 	technewsEngines := []SearchEngine{
-		{Name: "Hacker News", URL: "https://hn.algolia.com/?q=", SpaceDelimiter: "+"},
-		{Name: "Lobste.rs", URL: "https://lobste.rs/search?q=", SpaceDelimiter: "+"},
-		{Name: "Engadget", URL: "https://search.engadget.com/search?p=", SpaceDelimiter: "+"},
-		{Name: "The Verge", URL: "https://www.theverge.com/search?q=", SpaceDelimiter: "%20"},
+		{Name: "Hacker News", URL: "https://hn.algolia.com/?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "Lobste.rs", URL: "https://lobste.rs/search?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "Engadget", URL: "https://search.engadget.com/search?p=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "The Verge", URL: "https://www.theverge.com/search?q=", Encoding: Encoding{Delimiter: "%20"}},
 	}
 
 	newsEngines := []SearchEngine{
-		{Name: "NPR", URL: "https://www.npr.org/search/?query=", SpaceDelimiter: "%20"},
-		{Name: "NYT", URL: "https://www.nytimes.com/search?query=", SpaceDelimiter: "+"},
-		{Name: "WSJ", URL: "https://www.wsj.com/search?query=", SpaceDelimiter: "%20"},
+		{Name: "NPR", URL: "https://www.npr.org/search/?query=", Encoding: Encoding{Delimiter: "%20"}},
+		{Name: "NYT", URL: "https://www.nytimes.com/search?query=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "WSJ", URL: "https://www.wsj.com/search?query=", Encoding: Encoding{Delimiter: "%20"}},
 	}
 
 	tests := []struct {
@@ -398,7 +397,7 @@ func TestIntegration_NewCategoriesServiceSelection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			indices, err := ParseSelections(tt.selections, tt.engines)
+			indices, err := ParseSelections(nil, tt.selections, tt.engines)
 			if err != nil {
 				t.Fatalf("ParseSelections() error = %v", err)
 			}
@@ -422,144 +421,3 @@ func TestIntegration_NewCategoriesServiceSelection(t *testing.T) {
 		})
 	}
 }
-
-// TestIntegration_ExitCodes tests that the binary exits with correct exit codes
-// for different scenarios (help flags, missing arguments, etc.) and verifies
-// that output is routed to the correct stream (stdout vs stderr).
-func TestIntegration_ExitCodes(t *testing.T) {
-	// Build the binary for testing
-	tmpDir := t.TempDir()
-	binaryPath := filepath.Join(tmpDir, "hunt-test")
-
-	// Build command
-	buildCmd := exec.Command("go", "build", "-o", binaryPath, ".")
-	if output, err := buildCmd.CombinedOutput(); err != nil {
-		t.Fatalf("Failed to build binary: %v\nOutput: %s", err, output)
-	}
-
-	// Create a minimal search_engines.json in the test directory
-	jsonPath := filepath.Join(tmpDir, "search_engines.json")
-	testJSON := `{
-		"search": [
-			{"name": "Test", "url": "https://test.com/search?q=", "space_delimiter": "+"}
-		]
-	}`
-	if err := os.WriteFile(jsonPath, []byte(testJSON), 0444); err != nil {
-		t.Fatalf("Failed to create test JSON file: %v", err)
-	}
-
-	tests := []struct {
-		name           string
-		args           []string
-		wantExitCode   int
-		wantInStdout   []string
-		wantInStderr   []string
-	}{
-		{
-			name:         "help flag --help exits with 0 and writes to stdout",
-			args:         []string{"--help"},
-			wantExitCode: 0,
-			wantInStdout: []string{"Usage:", "Options:", "-h, --help"},
-			wantInStderr: []string{},
-		},
-		{
-			name:         "help flag -h exits with 0 and writes to stdout",
-			args:         []string{"-h"},
-			wantExitCode: 0,
-			wantInStdout: []string{"Usage:", "Options:", "-h, --help"},
-			wantInStderr: []string{},
-		},
-		{
-			name:         "no arguments exits with 1 and writes to stderr",
-			args:         []string{},
-			wantExitCode: 1,
-			wantInStdout: []string{},
-			wantInStderr: []string{"Usage:"},
-		},
-		{
-			name:         "help flag with subcommand exits with 0 and writes to stdout",
-			args:         []string{"shop", "--help"},
-			wantExitCode: 0,
-			wantInStdout: []string{"Usage:", "shop"},
-			wantInStderr: []string{},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Run the binary with the given args
-			cmd := exec.Command(binaryPath, tt.args...)
-			cmd.Dir = tmpDir // Set working directory so it finds search_engines.json
-
-			// Capture stdout and stderr separately
-			var stdout, stderr strings.Builder
-			cmd.Stdout = &stdout
-			cmd.Stderr = &stderr
-
-			err := cmd.Run()
-
-			// Get exit code
-			exitCode := 0
-			if err != nil {
-				if exitErr, ok := err.(*exec.ExitError); ok {
-					exitCode = exitErr.ExitCode()
-				} else {
-					t.Fatalf("Failed to run command: %v", err)
-				}
-			}
-
-			stdoutStr := stdout.String()
-			stderrStr := stderr.String()
-
-			// Check exit code
-			if exitCode != tt.wantExitCode {
-				t.Errorf("Exit code = %d, want %d\nStdout: %s\nStderr: %s",
-					exitCode, tt.wantExitCode, stdoutStr, stderrStr)
-			}
-
-			// Check stdout content
-			for _, want := range tt.wantInStdout {
-				if !strings.Contains(stdoutStr, want) {
-					t.Errorf("Stdout missing expected string %q\nGot: %s", want, stdoutStr)
-				}
-			}
-
-			// Verify stdout doesn't contain content meant for stderr
-			if len(tt.wantInStderr) > 0 && len(tt.wantInStdout) > 0 {
-				// If we expect stderr content, stdout should not contain it
-				for _, stderrContent := range tt.wantInStderr {
-					if strings.Contains(stdoutStr, stderrContent) {
-						t.Errorf("Stdout incorrectly contains stderr content %q\nStdout: %s",
-							stderrContent, stdoutStr)
-					}
-				}
-			}
-
-			// Check stderr content
-			for _, want := range tt.wantInStderr {
-				if !strings.Contains(stderrStr, want) {
-					t.Errorf("Stderr missing expected string %q\nGot: %s", want, stderrStr)
-				}
-			}
-
-			// Verify stderr doesn't contain content meant for stdout
-			if len(tt.wantInStdout) > 0 && len(tt.wantInStderr) > 0 {
-				// If we expect stdout content, stderr should not contain it
-				for _, stdoutContent := range tt.wantInStdout {
-					if strings.Contains(stderrStr, stdoutContent) {
-						t.Errorf("Stderr incorrectly contains stdout content %q\nStderr: %s",
-							stdoutContent, stderrStr)
-					}
-				}
-			}
-
-			// Verify expected empty streams are actually empty
-			if len(tt.wantInStdout) == 0 && stdoutStr != "" {
-				t.Errorf("Expected empty stdout, got: %s", stdoutStr)
-			}
-			if len(tt.wantInStderr) == 0 && stderrStr != "" {
-				t.Errorf("Expected empty stderr, got: %s", stderrStr)
-			}
-		})
-	}
-}
@@ -0,0 +1,140 @@
+package hunt
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	langPattern   = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+	regionPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// ParseLang validates a language code such as "de" or "pt-BR" and returns it lowercased
+func ParseLang(lang string) (string, error) {
+	lang = strings.ToLower(lang)
+	if !langPattern.MatchString(lang) {
+		return "", fmt.Errorf("invalid language %q (want a code such as de or pt-br)", lang)
+	}
+	return lang, nil
+}
+
+// ParseRegion validates a two-letter country code such as "DE" and returns it uppercased
+func ParseRegion(region string) (string, error) {
+	region = strings.ToUpper(region)
+	if !regionPattern.MatchString(region) {
+		return "", fmt.Errorf("invalid region %q (want a two-letter country code such as DE)", region)
+	}
+	return region, nil
+}
+
+// LocaleSettings holds the default --lang and --region from the "settings" block
+type LocaleSettings struct {
+	Lang   string `json:"lang,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// Validate checks the language and region codes
+func (s LocaleSettings) Validate() error {
+	if s.Lang != "" {
+		if _, err := ParseLang(s.Lang); err != nil {
+			return err
+		}
+	}
+	if s.Region != "" {
+		if _, err := ParseRegion(s.Region); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LocaleFilter maps --lang and --region onto an engine's URL parameters and domains
+type LocaleFilter struct {
+	Lang    map[string]string `json:"lang,omitempty"`    // Parameters for --lang; values may use {lang}
+	Region  map[string]string `json:"region,omitempty"`  // Parameters for --region; values may use {region}
+	Regions map[string]string `json:"regions,omitempty"` // Region to the engine's own code for {region}, e.g. "GB": "uk-en"
+	Domains map[string]string `json:"domains,omitempty"` // Region to the host to search, e.g. "DE": "www.amazon.de"
+}
+
+// Validate checks that region tables are keyed by country codes and have values
+func (l LocaleFilter) Validate() error {
+	for _, table := range []struct {
+		name   string
+		values map[string]string
+	}{{"regions", l.Regions}, {"domains", l.Domains}} {
+		for region, value := range table.values {
+			if !regionPattern.MatchString(region) {
+				return fmt.Errorf("locale: %s key %q is not an uppercase two-letter country code", table.name, region)
+			}
+			if value == "" {
+				return fmt.Errorf("locale: %s entry for %q is empty", table.name, region)
+			}
+		}
+	}
+	return nil
+}
+
+// langParams returns the parameters selecting lang, reporting false if the engine has none
+func (l LocaleFilter) langParams(lang string) (url.Values, bool) {
+	if len(l.Lang) == 0 {
+		return nil, false
+	}
+	params := url.Values{}
+	for name, value := range l.Lang {
+		params.Set(name, strings.ReplaceAll(value, "{lang}", lang))
+	}
+	return params, true
+}
+
+// regionParams returns the parameters and host selecting region
+// Reports false if the engine has no region support; it is an error for an engine that
+// lists its regions not to list this one.
+func (l LocaleFilter) regionParams(region string) (params url.Values, host string, ok bool, err error) {
+	if len(l.Region) == 0 && len(l.Domains) == 0 {
+		return nil, "", false, nil
+	}
+	if len(l.Domains) > 0 {
+		if host = l.Domains[region]; host == "" {
+			return nil, "", false, fmt.Errorf("no variant for region %s (has %s)", region, strings.Join(sortedKeys(l.Domains), ", "))
+		}
+	}
+	if len(l.Region) > 0 {
+		code := region
+		if len(l.Regions) > 0 {
+			if code = l.Regions[region]; code == "" {
+				return nil, "", false, fmt.Errorf("no variant for region %s (has %s)", region, strings.Join(sortedKeys(l.Regions), ", "))
+			}
+		}
+		params = url.Values{}
+		for name, value := range l.Region {
+			params.Set(name, strings.ReplaceAll(value, "{region}", code))
+		}
+	}
+	return params, host, true, nil
+}
+
+// replaceHost returns rawURL with its host replaced
+func replaceHost(rawURL, host string) string {
+	scheme, rest, ok := strings.Cut(rawURL, "://")
+	if !ok {
+		return rawURL
+	}
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		return scheme + "://" + host + rest[i:]
+	}
+	return scheme + "://" + host
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
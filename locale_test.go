@@ -0,0 +1,26 @@
+package hunt
+
+import "testing"
+
+func TestParseLangRegion(t *testing.T) {
+	for _, tt := range []struct {
+		lang, region         string
+		wantLang, wantRegion string
+		wantErr              bool
+	}{
+		{"de", "de", "de", "DE", false},
+		{"pt-BR", "BR", "pt-br", "BR", false},
+		{"deutsch", "DE", "", "", true},
+		{"de", "DEU", "", "", true},
+	} {
+		lang, langErr := ParseLang(tt.lang)
+		region, regionErr := ParseRegion(tt.region)
+		if gotErr := langErr != nil || regionErr != nil; gotErr != tt.wantErr {
+			t.Errorf("ParseLang(%q), ParseRegion(%q) errors = %v, %v, wantErr %v", tt.lang, tt.region, langErr, regionErr, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (lang != tt.wantLang || region != tt.wantRegion) {
+			t.Errorf("ParseLang(%q), ParseRegion(%q) = %q, %q, want %q, %q", tt.lang, tt.region, lang, region, tt.wantLang, tt.wantRegion)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package hunt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// openSearchNamespace is the XML namespace of OpenSearch 1.1 description documents
+const openSearchNamespace = "http://a9.com/-/spec/opensearch/1.1/"
+
+// openSearchPlaceholder marks where the query goes in an OpenSearch URL template
+const openSearchPlaceholder = "{searchTerms}"
+
+// OpenSearchDescription is an OpenSearch 1.1 description document
+type OpenSearchDescription struct {
+	XMLName       xml.Name        `xml:"http://a9.com/-/spec/opensearch/1.1/ OpenSearchDescription"`
+	ShortName     string          `xml:"ShortName"`
+	Description   string          `xml:"Description"`
+	InputEncoding string          `xml:"InputEncoding,omitempty"`
+	URLs          []OpenSearchURL `xml:"Url"`
+}
+
+// OpenSearchURL is a Url element: a search URL template containing {searchTerms}
+type OpenSearchURL struct {
+	Type     string            `xml:"type,attr"`
+	Method   string            `xml:"method,attr,omitempty"`
+	Template string            `xml:"template,attr"`
+	Params   []OpenSearchParam `xml:"Param,omitempty"` // Parameters extension, as written by Firefox
+}
+
+// OpenSearchParam is a name/value parameter added to a Url's query (or form, for POST)
+type OpenSearchParam struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteOpenSearchDescription writes desc as an indented XML document
+func WriteOpenSearchDescription(w io.Writer, desc OpenSearchDescription) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(desc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ParseOpenSearchDescription reads an OpenSearch description document
+// Documents without the OpenSearch 1.1 namespace are accepted, since browsers accept them too.
+func ParseOpenSearchDescription(r io.Reader) (OpenSearchDescription, error) {
+	// Same shape as OpenSearchDescription, but matching the root element in any namespace
+	var doc struct {
+		XMLName       xml.Name
+		ShortName     string          `xml:"ShortName"`
+		Description   string          `xml:"Description"`
+		InputEncoding string          `xml:"InputEncoding"`
+		URLs          []OpenSearchURL `xml:"Url"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return OpenSearchDescription{}, fmt.Errorf("failed to parse OpenSearch description: %w", err)
+	}
+	if doc.XMLName.Local != "OpenSearchDescription" {
+		return OpenSearchDescription{}, fmt.Errorf("not an OpenSearch description: root element is <%s>", doc.XMLName.Local)
+	}
+	return OpenSearchDescription{
+		XMLName:       xml.Name{Space: openSearchNamespace, Local: "OpenSearchDescription"},
+		ShortName:     strings.TrimSpace(doc.ShortName),
+		Description:   strings.TrimSpace(doc.Description),
+		InputEncoding: strings.TrimSpace(doc.InputEncoding),
+		URLs:          doc.URLs,
+	}, nil
+}
+
+// Engine converts the description's HTML search URL into a SearchEngine
+// A GET template must end with {searchTerms}, after any Param elements are appended to it;
+// a POST Url becomes a POST engine whose form fields are its Param elements.
+// A legacy InputEncoding becomes the engine's charset.
+func (d OpenSearchDescription) Engine() (SearchEngine, error) {
+	if d.ShortName == "" {
+		return SearchEngine{}, fmt.Errorf("no ShortName")
+	}
+	charset := d.InputEncoding
+	if strings.EqualFold(charset, "UTF-8") {
+		charset = ""
+	}
+	if _, err := (Encoding{Charset: charset}).charset(); err != nil {
+		return SearchEngine{}, fmt.Errorf("input encoding %s is not supported", d.InputEncoding)
+	}
+
+	var htmlURL *OpenSearchURL
+	for i, u := range d.URLs {
+		if u.Type == "text/html" || u.Type == "" {
+			htmlURL = &d.URLs[i]
+			break
+		}
+	}
+	if htmlURL == nil {
+		return SearchEngine{}, fmt.Errorf("no text/html Url")
+	}
+	switch method := strings.ToUpper(htmlURL.Method); method {
+	case "", MethodGet:
+	case MethodPost:
+		return d.postEngine(*htmlURL, charset)
+	default:
+		return SearchEngine{}, fmt.Errorf("%s searches are not supported", method)
+	}
+
+	template := htmlURL.ExpandParams()
+	prefix, ok := TemplateToPrefix(template, openSearchPlaceholder)
+	if !ok {
+		return SearchEngine{}, fmt.Errorf("{searchTerms} is not at the end of %s", template)
+	}
+	if strings.Contains(prefix, "{") {
+		return SearchEngine{}, fmt.Errorf("unsupported template parameters in %s", template)
+	}
+	enc := prefixEncoding(prefix)
+	enc.Charset = charset
+	return SearchEngine{Name: d.ShortName, URL: prefix, Encoding: enc}, nil
+}
+
+// postEngine converts a POST Url into a POST engine whose form is the Url's Param elements
+func (d OpenSearchDescription) postEngine(u OpenSearchURL, charset string) (SearchEngine, error) {
+	if strings.Contains(u.Template, "{") {
+		return SearchEngine{}, fmt.Errorf("unsupported template parameters in %s", u.Template)
+	}
+	engine := SearchEngine{Name: d.ShortName, URL: u.Template, Method: MethodPost, Encoding: Encoding{Charset: charset}}
+	for _, param := range u.Params {
+		value := strings.ReplaceAll(param.Value, openSearchPlaceholder, FormQueryPlaceholder)
+		if strings.Contains(strings.ReplaceAll(value, FormQueryPlaceholder, ""), "{") {
+			return SearchEngine{}, fmt.Errorf("unsupported template parameters in Param %s", param.Name)
+		}
+		engine.Form = append(engine.Form, FormField{Name: param.Name, Value: value})
+	}
+	if err := engine.validateMethod(); err != nil {
+		return SearchEngine{}, err
+	}
+	return engine, nil
+}
+
+// ExpandParams returns the URL template with any Param elements appended as query
+// parameters. A parameter whose value is {searchTerms} is moved last so the template
+// can still end with the query.
+func (u OpenSearchURL) ExpandParams() string {
+	if len(u.Params) == 0 {
+		return u.Template
+	}
+
+	var fixed, terms []string
+	for _, param := range u.Params {
+		pair := url.QueryEscape(param.Name) + "=" + param.Value
+		if strings.Contains(param.Value, openSearchPlaceholder) {
+			terms = append(terms, pair)
+		} else {
+			fixed = append(fixed, url.QueryEscape(param.Name)+"="+url.QueryEscape(param.Value))
+		}
+	}
+
+	separator := "?"
+	if strings.Contains(u.Template, "?") {
+		separator = "&"
+		if strings.HasSuffix(u.Template, "?") || strings.HasSuffix(u.Template, "&") {
+			separator = ""
+		}
+	}
+	return u.Template + separator + strings.Join(append(fixed, terms...), "&")
+}
+
+// prefixEncoding picks the encoding for a URL prefix: the default query escaping with "+"
+// when the query lands in the query string, path escaping with "%20" otherwise
+func prefixEncoding(prefix string) Encoding {
+	if strings.Contains(prefix, "?") {
+		return Encoding{Delimiter: "+"}
+	}
+	return Encoding{Escape: EscapePath, Delimiter: "%20"}
+}
+
+// EngineOpenSearchDescription builds an OpenSearch description for engine
+// OpenSearch has no notion of a space delimiter or case transform: browsers encode spaces
+// as "+" or "%20" and send the query as typed.
+func EngineOpenSearchDescription(engine SearchEngine) OpenSearchDescription {
+	inputEncoding := "UTF-8"
+	if engine.Encoding.Charset != "" {
+		inputEncoding = engine.Encoding.Charset
+	}
+	searchURL := OpenSearchURL{Type: "text/html", Method: "get", Template: engine.URL + openSearchPlaceholder}
+	if strings.EqualFold(engine.Method, MethodPost) {
+		searchURL = OpenSearchURL{Type: "text/html", Method: "post", Template: engine.URL}
+		for _, field := range engine.Form {
+			value := strings.ReplaceAll(field.Value, FormQueryPlaceholder, openSearchPlaceholder)
+			searchURL.Params = append(searchURL.Params, OpenSearchParam{Name: field.Name, Value: value})
+		}
+	}
+	return OpenSearchDescription{
+		ShortName:     engine.Name,
+		Description:   "Search " + engine.Name,
+		InputEncoding: inputEncoding,
+		URLs:          []OpenSearchURL{searchURL},
+	}
+}
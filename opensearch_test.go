@@ -0,0 +1,169 @@
+package hunt
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOpenSearchDescription_Engine(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		want    SearchEngine
+		wantErr string
+	}{
+		{
+			name: "query string template",
+			xml: `<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/">
+  <ShortName> Wikipedia </ShortName>
+  <InputEncoding>UTF-8</InputEncoding>
+  <Url type="application/x-suggestions+json" template="https://en.wikipedia.org/w/api.php?search={searchTerms}"/>
+  <Url type="text/html" method="get" template="https://en.wikipedia.org/w/index.php?search={searchTerms}"/>
+</OpenSearchDescription>`,
+			want: SearchEngine{Name: "Wikipedia", URL: "https://en.wikipedia.org/w/index.php?search=", Encoding: Encoding{Delimiter: "+"}},
+		},
+		{
+			name: "no namespace, path template",
+			xml: `<OpenSearchDescription>
+  <ShortName>Docs</ShortName>
+  <Url type="text/html" template="https://docs.example.com/search/{searchTerms}"/>
+</OpenSearchDescription>`,
+			want: SearchEngine{Name: "Docs", URL: "https://docs.example.com/search/", Encoding: Encoding{Escape: EscapePath, Delimiter: "%20"}},
+		},
+		{
+			name: "other root element",
+			xml: `<SearchPlugin xmlns="http://www.mozilla.org/2006/browser/search/">
+  <ShortName>Example</ShortName>
+  <Url type="text/html" template="https://example.com/find">
+    <Param name="q" value="{searchTerms}"/>
+    <Param name="lang" value="en us"/>
+  </Url>
+</SearchPlugin>`,
+			wantErr: "not an OpenSearch description",
+		},
+		{
+			name: "legacy encoding",
+			xml: `<OpenSearchDescription>
+  <ShortName>Legacy</ShortName>
+  <InputEncoding>Shift_JIS</InputEncoding>
+  <Url type="text/html" template="https://legacy.example.jp/?q={searchTerms}"/>
+</OpenSearchDescription>`,
+			want: SearchEngine{Name: "Legacy", URL: "https://legacy.example.jp/?q=", Encoding: Encoding{Delimiter: "+", Charset: "Shift_JIS"}},
+		},
+		{
+			name: "unknown encoding",
+			xml: `<OpenSearchDescription>
+  <ShortName>Unknown</ShortName>
+  <InputEncoding>x-klingon</InputEncoding>
+  <Url type="text/html" template="https://example.com/?q={searchTerms}"/>
+</OpenSearchDescription>`,
+			wantErr: "input encoding",
+		},
+		{
+			name: "POST",
+			xml: `<OpenSearchDescription>
+  <ShortName>Catalog</ShortName>
+  <Url type="text/html" method="post" template="https://catalog.example.edu/search">
+    <Param name="type" value="keyword"/>
+    <Param name="terms" value="{searchTerms}"/>
+  </Url>
+</OpenSearchDescription>`,
+			want: SearchEngine{Name: "Catalog", URL: "https://catalog.example.edu/search", Method: MethodPost, Form: []FormField{
+				{Name: "type", Value: "keyword"},
+				{Name: "terms", Value: "{query}"},
+			}},
+		},
+		{
+			name: "POST without the query",
+			xml: `<OpenSearchDescription>
+  <ShortName>Poster</ShortName>
+  <Url type="text/html" method="post" template="https://example.com/search"/>
+</OpenSearchDescription>`,
+			wantErr: "need a form field containing {query}",
+		},
+		{
+			name: "other methods",
+			xml: `<OpenSearchDescription>
+  <ShortName>Putter</ShortName>
+  <Url type="text/html" method="put" template="https://example.com/search?q={searchTerms}"/>
+</OpenSearchDescription>`,
+			wantErr: "PUT searches are not supported",
+		},
+		{
+			name: "searchTerms mid-template",
+			xml: `<OpenSearchDescription>
+  <ShortName>Middle</ShortName>
+  <Url type="text/html" template="https://example.com/search?q={searchTerms}&amp;page=1"/>
+</OpenSearchDescription>`,
+			wantErr: "not at the end",
+		},
+		{
+			name: "other template parameters",
+			xml: `<OpenSearchDescription>
+  <ShortName>Paged</ShortName>
+  <Url type="text/html" template="https://example.com/search?start={startIndex?}&amp;q={searchTerms}"/>
+</OpenSearchDescription>`,
+			wantErr: "unsupported template parameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, err := ParseOpenSearchDescription(strings.NewReader(tt.xml))
+			if err == nil {
+				var engine SearchEngine
+				engine, err = desc.Engine()
+				if err == nil && !reflect.DeepEqual(engine, tt.want) {
+					t.Errorf("Engine() = %+v, want %+v", engine, tt.want)
+				}
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpenSearchURL_ExpandParams(t *testing.T) {
+	u := OpenSearchURL{
+		Template: "https://example.com/find",
+		Params: []OpenSearchParam{
+			{Name: "q", Value: "{searchTerms}"},
+			{Name: "lang", Value: "en us"},
+		},
+	}
+	want := "https://example.com/find?lang=en+us&q={searchTerms}"
+	if got := u.ExpandParams(); got != want {
+		t.Errorf("ExpandParams() = %q, want %q", got, want)
+	}
+}
+
+func TestEngineOpenSearchDescription_RoundTrip(t *testing.T) {
+	engines := []SearchEngine{
+		{Name: "Hacker News", URL: "https://hn.algolia.com/?q=", Encoding: Encoding{Delimiter: "+"}},
+		{Name: "Catalog", URL: "https://catalog.example.edu/search", Method: MethodPost, Form: []FormField{{Name: "terms", Value: "{query}"}}},
+	}
+
+	for _, engine := range engines {
+		var buf bytes.Buffer
+		if err := WriteOpenSearchDescription(&buf, EngineOpenSearchDescription(engine)); err != nil {
+			t.Fatal(err)
+		}
+		desc, err := ParseOpenSearchDescription(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := desc.Engine()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, engine) {
+			t.Errorf("round trip = %+v, want %+v", got, engine)
+		}
+	}
+}
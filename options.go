@@ -0,0 +1,204 @@
+package hunt
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Search option names used in UnsupportedOptions
+const (
+	OptionDate      = "date range"
+	OptionLang      = "language"
+	OptionRegion    = "region"
+	OptionSafe      = "safe search"
+	OptionPage      = "results page"
+	OptionNum       = "results per page"
+	OptionSort      = "sort"
+	OptionPrice     = "price range"
+	OptionCondition = "condition"
+)
+
+// SearchOptions are filters applied to every selected engine on top of the query
+// Each engine declares how (and whether) it supports them in search_engines.json.
+type SearchOptions struct {
+	Since time.Time // Zero when unset
+	Until time.Time // Zero when unset; the day itself is included
+	Past  string    // One of Periods; not combined with Since or Until
+
+	Lang   string // Lowercase language code, e.g. "de"
+	Region string // Uppercase country code, e.g. "DE"
+
+	SafeSearch string // One of SafeSearchLevels
+
+	Page int // 1-based results page; 0 means the first page
+	Num  int // Results per page; 0 means the engine's default
+
+	// Shopping filters
+	Sort      string  // One of SortOrders
+	MinPrice  float64 // 0 means no minimum
+	MaxPrice  float64 // 0 means no maximum
+	Condition string  // One of Conditions
+
+	Now time.Time // Anchors Past for engines that only support date ranges; zero means time.Now()
+}
+
+// now returns opts.Now, or the current time if it is unset
+func (opts SearchOptions) now() time.Time {
+	if opts.Now.IsZero() {
+		return time.Now()
+	}
+	return opts.Now
+}
+
+// Validate checks that the options are consistent
+func (opts SearchOptions) Validate() error {
+	if opts.Past != "" && (!opts.Since.IsZero() || !opts.Until.IsZero()) {
+		return fmt.Errorf("--past can't be combined with --since or --until")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		return fmt.Errorf("--until %s is before --since %s", opts.Until.Format(DateLayout), opts.Since.Format(DateLayout))
+	}
+	if opts.Page < 0 {
+		return fmt.Errorf("invalid results page %d", opts.Page)
+	}
+	if opts.Num < 0 {
+		return fmt.Errorf("invalid results per page %d", opts.Num)
+	}
+	if opts.MinPrice < 0 || opts.MaxPrice < 0 {
+		return fmt.Errorf("prices can't be negative")
+	}
+	if opts.MaxPrice > 0 && opts.MaxPrice < opts.MinPrice {
+		return fmt.Errorf("--max-price %s is below --min-price %s", formatPrice(opts.MaxPrice), formatPrice(opts.MinPrice))
+	}
+	return nil
+}
+
+// UnsupportedOptions lists the search options an engine searched without
+type UnsupportedOptions struct {
+	Engine  string
+	Options []string
+}
+
+// WithOptions returns the engine with opts added to its request, plus the options it
+// has no mapping for. Those are left out and the engine searches without them. It is an
+// error to ask for a region the engine has variants for, but not this one.
+func (e SearchEngine) WithOptions(opts SearchOptions) (SearchEngine, []string, error) {
+	params := url.Values{}
+	var needParams, unsupported []string
+	add := func(option string, optionParams url.Values, ok bool) {
+		if !ok {
+			unsupported = append(unsupported, option)
+			return
+		}
+		if len(optionParams) > 0 {
+			needParams = append(needParams, option)
+			addValues(params, optionParams)
+		}
+	}
+
+	if opts.Past != "" || !opts.Since.IsZero() || !opts.Until.IsZero() {
+		dateParams, ok := e.Date.params(opts)
+		add(OptionDate, dateParams, ok)
+	}
+	if opts.Lang != "" {
+		langParams, ok := e.Locale.langParams(opts.Lang)
+		add(OptionLang, langParams, ok)
+	}
+	if opts.Region != "" {
+		regionParams, host, ok, err := e.Locale.regionParams(opts.Region)
+		if err != nil {
+			return e, nil, fmt.Errorf("%s: %w", e.Name, err)
+		}
+		if host != "" {
+			e.URL = replaceHost(e.URL, host)
+		}
+		add(OptionRegion, regionParams, ok)
+	}
+
+	if opts.SafeSearch != "" {
+		safeParams, ok := e.SafeSearch.params(opts.SafeSearch)
+		add(OptionSafe, safeParams, ok)
+	}
+
+	numApplied := 0
+	if opts.Num > 0 {
+		numParams, ok := e.Paging.numParams(opts.Num)
+		add(OptionNum, numParams, ok)
+		if ok {
+			numApplied = opts.Num
+		}
+	}
+	if opts.Page > 0 {
+		pageParams, ok := e.Paging.pageParams(opts.Page, numApplied)
+		add(OptionPage, pageParams, ok)
+	}
+
+	if opts.Sort != "" {
+		sortParams, ok := e.Facets.sortParams(opts.Sort)
+		add(OptionSort, sortParams, ok)
+	}
+	if opts.MinPrice > 0 || opts.MaxPrice > 0 {
+		priceParams, ok := e.Facets.priceParams(opts.MinPrice, opts.MaxPrice)
+		add(OptionPrice, priceParams, ok)
+	}
+	if opts.Condition != "" {
+		conditionParams, ok := e.Facets.conditionParams(opts.Condition)
+		add(OptionCondition, conditionParams, ok)
+	}
+
+	if len(params) == 0 {
+		return e, unsupported, nil
+	}
+	engine, ok := e.withParams(params)
+	if !ok {
+		// The URL has nowhere to put parameters (e.g., the query is a path segment)
+		return e, append(unsupported, needParams...), nil
+	}
+	return engine, unsupported, nil
+}
+
+// ApplySearchOptions applies opts to each selected engine
+// Returns the updated engines and, for each engine that couldn't apply every option, what it left out.
+func ApplySearchOptions(selected []SelectedEngine, opts SearchOptions) ([]SelectedEngine, []UnsupportedOptions, error) {
+	applied := make([]SelectedEngine, len(selected))
+	var unsupported []UnsupportedOptions
+	for i, s := range selected {
+		engine, missing, err := s.Engine.WithOptions(opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		applied[i] = SelectedEngine{Category: s.Category, Engine: engine}
+		if len(missing) > 0 {
+			unsupported = append(unsupported, UnsupportedOptions{Engine: s.Engine.Name, Options: missing})
+		}
+	}
+	return applied, unsupported, nil
+}
+
+// withParams adds params to the engine's request ahead of the query
+// GET engines get them in the URL before the query parameter, which must end the URL so
+// the query can still be appended. POST engines get extra form fields.
+func (e SearchEngine) withParams(params url.Values) (SearchEngine, bool) {
+	if strings.EqualFold(e.Method, MethodPost) {
+		e.Form = append(slices.Clip(e.Form), paramFields(params)...)
+		return e, true
+	}
+	if _, _, ok := splitQueryPrefix(e.URL); !ok {
+		return e, false
+	}
+	base, rawQuery, _ := strings.Cut(e.URL, "?")
+	e.URL = base + "?" + params.Encode() + "&" + rawQuery
+	return e, true
+}
+
+// addValues adds every value in src to dst
+func addValues(dst, src url.Values) {
+	for name, values := range src {
+		for _, value := range values {
+			dst.Add(name, value)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package hunt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchEngine_WithOptions(t *testing.T) {
+	date := DateFilter{Past: map[string]map[string]string{PeriodWeek: {"df": "w"}}}
+	week := SearchOptions{Past: PeriodWeek}
+	google := SearchEngine{Name: "Google", URL: "https://www.google.com/search?q=",
+		Locale: LocaleFilter{Lang: map[string]string{"hl": "{lang}"}, Region: map[string]string{"gl": "{region}"}}}
+	ddg := SearchEngine{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=",
+		Locale: LocaleFilter{Region: map[string]string{"kl": "{region}"}, Regions: map[string]string{"GB": "uk-en"}}}
+	amazon := SearchEngine{Name: "Amazon", URL: "https://www.amazon.com/s?k=",
+		Locale: LocaleFilter{Domains: map[string]string{"DE": "www.amazon.de", "GB": "www.amazon.co.uk"}}}
+	kagi := SearchEngine{Name: "Kagi", URL: "https://kagi.com/search?q="}
+	pagedGoogle := SearchEngine{Name: "Google", URL: "https://www.google.com/search?q=",
+		Paging: PagingFilter{Page: map[string]string{"start": "{offset}"}, Num: map[string]string{"num": "{num}"}}}
+	bing := SearchEngine{Name: "Bing", URL: "https://www.bing.com/search?q=",
+		Paging: PagingFilter{Page: map[string]string{"first": "{offset1}"}}}
+	ebay := SearchEngine{Name: "eBay", URL: "https://www.ebay.com/sch/i.html?_nkw=",
+		Paging: PagingFilter{Page: map[string]string{"_pgn": "{page}"}, PageSize: 60}}
+	hn := SearchEngine{Name: "Hacker News", URL: "https://hn.algolia.com/?q=",
+		Paging: PagingFilter{Page: map[string]string{"page": "{page0}"}}}
+	shopEbay := SearchEngine{Name: "eBay", URL: "https://www.ebay.com/sch/i.html?_nkw=", Facets: ShopFacets{
+		Sort:      map[string]map[string]string{SortPriceAsc: {"_sop": "15"}},
+		Price:     map[string]string{"_udlo": "{min}", "_udhi": "{max}"},
+		Condition: map[string]map[string]string{ConditionUsed: {"LH_ItemCondition": "3000"}},
+	}}
+	shopAmazon := SearchEngine{Name: "Amazon", URL: "https://www.amazon.com/s?k=", Facets: ShopFacets{
+		Price: map[string]string{"rh": "p_36:{min_cents}-{max_cents}"},
+	}}
+	safeGoogle := SearchEngine{Name: "Google", URL: "https://www.google.com/search?q=", SafeSearch: SafeSearchFilter{
+		SafeStrict: {"safe": "active"},
+		SafeOff:    {"safe": "off"},
+	}}
+
+	tests := []struct {
+		name            string
+		engine          SearchEngine
+		opts            SearchOptions
+		wantURL         string
+		wantForm        []FormField
+		wantUnsupported []string
+		wantErr         string
+	}{
+		{
+			name:    "no options",
+			engine:  SearchEngine{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=", Date: date},
+			wantURL: "https://duckduckgo.com/?q=",
+		},
+		{
+			name:    "params go before the query",
+			engine:  SearchEngine{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?ia=web&q=", Date: date},
+			opts:    week,
+			wantURL: "https://duckduckgo.com/?df=w&ia=web&q=",
+		},
+		{
+			name:            "unsupported option",
+			engine:          SearchEngine{Name: "Mojeek", URL: "https://www.mojeek.com/search?q="},
+			opts:            week,
+			wantURL:         "https://www.mojeek.com/search?q=",
+			wantUnsupported: []string{OptionDate},
+		},
+		{
+			name:            "query in the path",
+			engine:          SearchEngine{Name: "Maps", URL: "https://www.google.com/maps/search/", Date: date},
+			opts:            week,
+			wantURL:         "https://www.google.com/maps/search/",
+			wantUnsupported: []string{OptionDate},
+		},
+		{
+			name: "POST form fields",
+			engine: SearchEngine{Name: "Catalog", URL: "https://catalog.example.edu/search", Method: MethodPost, Date: date,
+				Form: []FormField{{Name: "terms", Value: FormQueryPlaceholder}}},
+			opts:     week,
+			wantURL:  "https://catalog.example.edu/search",
+			wantForm: []FormField{{Name: "terms", Value: FormQueryPlaceholder}, {Name: "df", Value: "w"}},
+		},
+		{name: "locale params", engine: google, opts: SearchOptions{Lang: "de", Region: "DE"}, wantURL: "https://www.google.com/search?gl=DE&hl=de&q="},
+		{name: "region code table", engine: ddg, opts: SearchOptions{Region: "GB"}, wantURL: "https://duckduckgo.com/?kl=uk-en&q="},
+		{name: "no language support", engine: ddg, opts: SearchOptions{Lang: "en", Region: "GB"}, wantURL: "https://duckduckgo.com/?kl=uk-en&q=", wantUnsupported: []string{OptionLang}},
+		{name: "region missing from code table", engine: ddg, opts: SearchOptions{Region: "DE"}, wantErr: "DuckDuckGo: no variant for region DE (has GB)"},
+		{name: "region domain", engine: amazon, opts: SearchOptions{Region: "GB"}, wantURL: "https://www.amazon.co.uk/s?k="},
+		{name: "region missing from domains", engine: amazon, opts: SearchOptions{Region: "FR"}, wantErr: "Amazon: no variant for region FR (has DE, GB)"},
+		{name: "no region support", engine: kagi, opts: SearchOptions{Region: "DE"}, wantURL: "https://kagi.com/search?q=", wantUnsupported: []string{OptionRegion}},
+		{name: "safe search strict", engine: safeGoogle, opts: SearchOptions{SafeSearch: SafeStrict}, wantURL: "https://www.google.com/search?safe=active&q="},
+		{name: "safe search off", engine: safeGoogle, opts: SearchOptions{SafeSearch: SafeOff}, wantURL: "https://www.google.com/search?safe=off&q="},
+		{name: "safe search level without a mapping", engine: safeGoogle, opts: SearchOptions{SafeSearch: SafeModerate}, wantURL: "https://www.google.com/search?q=", wantUnsupported: []string{OptionSafe}},
+		{name: "page offset", engine: pagedGoogle, opts: SearchOptions{Page: 3}, wantURL: "https://www.google.com/search?start=20&q="},
+		{name: "page offset uses num", engine: pagedGoogle, opts: SearchOptions{Page: 3, Num: 50}, wantURL: "https://www.google.com/search?num=50&start=100&q="},
+		{name: "1-based page offset", engine: bing, opts: SearchOptions{Page: 2}, wantURL: "https://www.bing.com/search?first=11&q="},
+		{name: "num unsupported keeps the default page size", engine: bing, opts: SearchOptions{Page: 2, Num: 50}, wantURL: "https://www.bing.com/search?first=11&q=", wantUnsupported: []string{OptionNum}},
+		{name: "page number", engine: ebay, opts: SearchOptions{Page: 2}, wantURL: "https://www.ebay.com/sch/i.html?_pgn=2&_nkw="},
+		{name: "0-based page", engine: hn, opts: SearchOptions{Page: 2}, wantURL: "https://hn.algolia.com/?page=1&q="},
+		{name: "no paging", engine: kagi, opts: SearchOptions{Page: 2}, wantURL: "https://kagi.com/search?q=", wantUnsupported: []string{OptionPage}},
+		{name: "sort and condition", engine: shopEbay, opts: SearchOptions{Sort: SortPriceAsc, Condition: ConditionUsed}, wantURL: "https://www.ebay.com/sch/i.html?LH_ItemCondition=3000&_sop=15&_nkw="},
+		{name: "price range", engine: shopEbay, opts: SearchOptions{MinPrice: 50, MaxPrice: 199.99}, wantURL: "https://www.ebay.com/sch/i.html?_udhi=199.99&_udlo=50&_nkw="},
+		{name: "open-ended price range drops the empty parameter", engine: shopEbay, opts: SearchOptions{MaxPrice: 300}, wantURL: "https://www.ebay.com/sch/i.html?_udhi=300&_nkw="},
+		{name: "price in cents", engine: shopAmazon, opts: SearchOptions{MinPrice: 49.99, MaxPrice: 100}, wantURL: "https://www.amazon.com/s?rh=p_36%3A4999-10000&k="},
+		{name: "unsupported facets", engine: shopAmazon, opts: SearchOptions{Sort: SortNewest, Condition: ConditionRefurbished}, wantURL: "https://www.amazon.com/s?k=", wantUnsupported: []string{OptionSort, OptionCondition}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unsupported, err := tt.engine.WithOptions(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("WithOptions() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WithOptions() error = %v", err)
+			}
+			if got.URL != tt.wantURL {
+				t.Errorf("WithOptions() URL = %q, want %q", got.URL, tt.wantURL)
+			}
+			if tt.wantForm != nil && !reflect.DeepEqual(got.Form, tt.wantForm) {
+				t.Errorf("WithOptions() form = %+v, want %+v", got.Form, tt.wantForm)
+			}
+			if !reflect.DeepEqual(unsupported, tt.wantUnsupported) {
+				t.Errorf("WithOptions() unsupported = %v, want %v", unsupported, tt.wantUnsupported)
+			}
+		})
+	}
+}
+
+func TestApplySearchOptions(t *testing.T) {
+	selected := []SelectedEngine{
+		{Category: "search", Engine: SearchEngine{Name: "Bing", URL: "https://www.bing.com/search?q=",
+			Date: DateFilter{Past: map[string]map[string]string{PeriodDay: {"freshness": "Day"}}}}},
+		{Category: "search", Engine: SearchEngine{Name: "Kagi", URL: "https://kagi.com/search?q="}},
+	}
+
+	applied, unsupported, err := ApplySearchOptions(selected, SearchOptions{Past: PeriodDay})
+	if err != nil {
+		t.Fatalf("ApplySearchOptions() error = %v", err)
+	}
+	if got, want := BuildSearchURL(applied[0].Engine, "go"), "https://www.bing.com/search?freshness=Day&q=go"; got != want {
+		t.Errorf("Bing URL = %q, want %q", got, want)
+	}
+	if want := []UnsupportedOptions{{Engine: "Kagi", Options: []string{OptionDate}}}; !reflect.DeepEqual(unsupported, want) {
+		t.Errorf("unsupported = %+v, want %+v", unsupported, want)
+	}
+	if selected[0].Engine.URL != "https://www.bing.com/search?q=" {
+		t.Error("ApplySearchOptions() modified the selected engines")
+	}
+}
+
+func TestSearchOptions_Validate(t *testing.T) {
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb1 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		opts    SearchOptions
+		wantErr bool
+	}{
+		{"empty", SearchOptions{}, false},
+		{"range", SearchOptions{Since: jan1, Until: feb1}, false},
+		{"same day", SearchOptions{Since: jan1, Until: jan1}, false},
+		{"until before since", SearchOptions{Since: feb1, Until: jan1}, true},
+		{"past with since", SearchOptions{Past: PeriodWeek, Since: jan1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package hunt
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+)
+
+// PageLink is one engine's link on the landing page
+type PageLink struct {
+	Category string
+	Engine   SearchEngine
+	URL      string
+	Fixed    bool // URL doesn't take the query as a suffix (e.g., a POST form page), so edits can't retarget it
+}
+
+// pageGroup is a category heading and its links, in display order
+type pageGroup struct {
+	Title string
+	Links []PageLink
+}
+
+// pageEngine is the per-engine data the page script needs to rebuild links for an edited query
+type pageEngine struct {
+	Name       string           `json:"name"`
+	Prefix     string           `json:"prefix"`
+	Encoding   Encoding         `json:"encoding"`
+	Transforms []QueryTransform `json:"transforms,omitempty"`
+	Fixed      bool             `json:"fixed,omitempty"`
+}
+
+// groupPageLinks groups links by category, keeping categories in order of first appearance
+func groupPageLinks(links []PageLink) []pageGroup {
+	var groups []pageGroup
+	index := make(map[string]int)
+	for _, link := range links {
+		i, ok := index[link.Category]
+		if !ok {
+			i = len(groups)
+			index[link.Category] = i
+			groups = append(groups, pageGroup{Title: FormatCategoryName(link.Category)})
+		}
+		groups[i].Links = append(groups[i].Links, link)
+	}
+	return groups
+}
+
+// landingPageData is everything the landing page template renders
+type landingPageData struct {
+	Query         string
+	Groups        []pageGroup
+	Engines       []pageEngine
+	Operators     map[string]bool // Operator names for the script's strip_operators step
+	OpenSearchURL string          // Advertised OpenSearch descriptor, when served over HTTP
+}
+
+// newLandingPageData prepares the template data for a query and its links
+// Engines follow the grouped display order, since the script pairs the nth link with the nth engine
+func newLandingPageData(query string, links []PageLink) landingPageData {
+	groups := groupPageLinks(links)
+	engines := make([]pageEngine, 0, len(links))
+	for _, group := range groups {
+		for _, link := range group.Links {
+			engines = append(engines, newPageEngine(link))
+		}
+	}
+
+	return landingPageData{
+		Query:     query,
+		Groups:    groups,
+		Engines:   engines,
+		Operators: searchOperators,
+	}
+}
+
+// newPageEngine prepares the script data for one link
+func newPageEngine(link PageLink) pageEngine {
+	engine := pageEngine{
+		Name:       link.Engine.Name,
+		Prefix:     link.Engine.URL,
+		Encoding:   link.Engine.Encoding.withDefaults(),
+		Transforms: link.Engine.QueryTransform,
+		Fixed:      link.Fixed,
+	}
+	// The script approximates the strip syntax with a strip_operators step, but can't add URL parameters
+	if link.Engine.Operators.Syntax == SyntaxStrip {
+		engine.Transforms = append([]QueryTransform{{Op: TransformStripOperators}}, engine.Transforms...)
+	}
+	if len(link.Engine.Operators.Params) > 0 {
+		engine.Fixed = true
+	}
+	for _, step := range link.Engine.QueryTransform {
+		// Go and JavaScript regular expressions differ, so the script doesn't replay replace steps
+		if step.Op == TransformReplace {
+			engine.Fixed = true
+		}
+	}
+	return engine
+}
+
+// LandingPage is a self-contained HTML page listing every link, grouped by category
+// The page works offline: the query can be edited and every link is re-targeted client-side
+type LandingPage struct {
+	Query         string
+	Links         []PageLink
+	OpenSearchURL string // Optional OpenSearch descriptor to advertise, when served over HTTP
+}
+
+// Render writes the page to w
+func (p LandingPage) Render(w io.Writer) error {
+	data := newLandingPageData(p.Query, p.Links)
+	data.OpenSearchURL = p.OpenSearchURL
+	return landingPageTemplate.Execute(w, data)
+}
+
+// WriteLandingPage renders the landing page for query and links to w
+func WriteLandingPage(w io.Writer, query string, links []PageLink) error {
+	return LandingPage{Query: query, Links: links}.Render(w)
+}
+
+// WriteLandingPageFile writes the landing page to a new file in the system temp directory
+// and returns its path. The file is left in place so the browser can load it after hunt exits.
+func WriteLandingPageFile(query string, links []PageLink) (string, error) {
+	f, err := os.CreateTemp("", "hunt-*.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to create landing page: %w", err)
+	}
+	defer f.Close()
+
+	if err := WriteLandingPage(f, query, links); err != nil {
+		return "", fmt.Errorf("failed to write landing page: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write landing page: %w", err)
+	}
+	return f.Name(), nil
+}
+
+var landingPageTemplate = template.Must(template.New("landing").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>hunt: {{.Query}}</title>
+{{if .OpenSearchURL}}<link rel="search" type="application/opensearchdescription+xml" title="hunt" href="{{.OpenSearchURL}}">
+{{end}}<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+  form { display: flex; gap: .5rem; margin-bottom: 1rem; }
+  input[type=search] { flex: 1; font-size: 1.1rem; padding: .4rem .6rem; }
+  button { font-size: 1rem; padding: .4rem .8rem; cursor: pointer; }
+  .actions { display: flex; flex-wrap: wrap; gap: .5rem; margin-bottom: 1.5rem; }
+  h2 { font-size: 1.1rem; border-bottom: 1px solid #ddd; padding-bottom: .25rem; }
+  ul { list-style: none; padding: 0; }
+  li { margin: .4rem 0; }
+  li.opened a { color: #888; }
+  li.next a { font-weight: bold; }
+  .hint { color: #666; font-size: .9rem; }
+</style>
+</head>
+<body>
+<h1>hunt</h1>
+<form id="query-form">
+  <input type="search" id="query" value="{{.Query}}" aria-label="Search query" autofocus>
+  <button type="submit">Update links</button>
+</form>
+<div class="actions">
+  <button type="button" id="open-all">Open all</button>
+  <button type="button" id="open-background">Open all in background</button>
+  <button type="button" id="open-next">Open next</button>
+</div>
+<p class="hint">If only one tab opens, allow pop-ups for this page, or use "Open next" to open one link per click.</p>
+{{range .Groups}}
+<h2>{{.Title}}</h2>
+<ul>
+{{range .Links}}  <li><a class="engine-link" href="{{.URL}}" target="_blank" rel="noopener">{{.Engine.Name}}</a></li>
+{{end}}</ul>
+{{end}}
+<script>
+(function () {
+  var engines = {{.Engines}};
+  var links = Array.prototype.slice.call(document.querySelectorAll("a.engine-link"));
+  var next = 0;
+
+  var operators = {{.Operators}};
+
+  // Mirrors splitQueryTerms: spaces inside double quotes don't end a term
+  function splitTerms(query) {
+    var terms = [];
+    var term = "";
+    var quoted = false;
+    for (var i = 0; i < query.length; i++) {
+      var c = query[i];
+      if (c === '"') {
+        quoted = !quoted;
+        term += c;
+      } else if (!quoted && (c === " " || c === "\t" || c === "\n")) {
+        if (term) {
+          terms.push(term);
+        }
+        term = "";
+      } else {
+        term += c;
+      }
+    }
+    if (term) {
+      terms.push(term);
+    }
+    return terms;
+  }
+
+  // Mirrors parseQueryNode and the strip operator dialect: returns what the term keeps, or ""
+  function stripTerm(term) {
+    if (term === "OR" || term === "AND" || term === "|" || (term.length > 1 && term[0] === "-")) {
+      return "";
+    }
+    var colon = term.indexOf(":");
+    if (colon > 0 && colon < term.length - 1 && operators[term.slice(0, colon).toLowerCase()]) {
+      return "";
+    }
+    if (term[0] === '"') {
+      return term.replace(/^"+|"+$/g, "");
+    }
+    return term.replace(/^["+]+|["+]+$/g, "");
+  }
+
+  // Mirrors SearchEngine.TransformQuery for every step except replace
+  function transform(query, steps) {
+    (steps || []).forEach(function (step) {
+      if (step.op === "prefix") {
+        query = step.value + query;
+      } else if (step.op === "suffix") {
+        query = query + step.value;
+      } else if (step.op === "quote") {
+        query = '"' + query.trim().split('"').join("") + '"';
+      } else if (step.op === "strip_operators") {
+        query = splitTerms(query).map(stripTerm).filter(Boolean).join(" ");
+      }
+    });
+    return query;
+  }
+
+  // Mirrors Encoding.Encode. Legacy charsets can't be produced in the browser, so an
+  // edited query is always sent as UTF-8.
+  function encode(query, encoding) {
+    if (encoding.case === "lower") {
+      query = query.toLowerCase();
+    } else if (encoding.case === "upper") {
+      query = query.toUpperCase();
+    }
+    var encoded = encodeURIComponent(query).replace(/[!'()*]/g, function (c) {
+      return "%" + c.charCodeAt(0).toString(16).toUpperCase();
+    });
+    if (encoding.escape === "path") {
+      // url.PathEscape leaves these unescaped in a path segment
+      encoded = encoded.replace(/%(24|26|2B|3A|3D|40)/g, function (m, hex) {
+        return String.fromCharCode(parseInt(hex, 16));
+      });
+    }
+    return encoded.split("%20").join(encoding.delimiter);
+  }
+
+  function retarget(query) {
+    links.forEach(function (link, i) {
+      // Fixed links (such as POST form pages) keep the original query
+      if (!engines[i].fixed) {
+        link.href = engines[i].prefix + encode(transform(query, engines[i].transforms), engines[i].encoding);
+      }
+      link.parentNode.classList.remove("opened");
+    });
+    document.title = "hunt: " + query;
+    next = 0;
+    markNext();
+  }
+
+  function markNext() {
+    links.forEach(function (link, i) {
+      link.parentNode.classList.toggle("next", i === next);
+    });
+  }
+
+  function open(i) {
+    window.open(links[i].href, "_blank", "noopener");
+    links[i].parentNode.classList.add("opened");
+  }
+
+  document.getElementById("query-form").addEventListener("submit", function (e) {
+    e.preventDefault();
+    retarget(document.getElementById("query").value);
+  });
+  document.getElementById("open-all").addEventListener("click", function () {
+    links.forEach(function (link, i) { open(i); });
+  });
+  document.getElementById("open-background").addEventListener("click", function () {
+    links.forEach(function (link, i) { open(i); });
+    // Bring this page back to the front so the new tabs stay in the background
+    window.focus();
+  });
+  document.getElementById("open-next").addEventListener("click", function () {
+    if (next < links.length) {
+      open(next);
+      next++;
+      markNext();
+    }
+  });
+  links.forEach(function (link) {
+    link.addEventListener("click", function () { link.parentNode.classList.add("opened"); });
+  });
+  markNext();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,119 @@
+package hunt
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGroupPageLinks(t *testing.T) {
+	links := []PageLink{
+		{Category: "search", Engine: SearchEngine{Name: "Bing"}},
+		{Category: "shop", Engine: SearchEngine{Name: "Amazon"}},
+		{Category: "search", Engine: SearchEngine{Name: "Google"}},
+	}
+
+	groups := groupPageLinks(links)
+	if len(groups) != 2 {
+		t.Fatalf("groupPageLinks() returned %d groups, want 2", len(groups))
+	}
+	if groups[0].Title != "Search Engines" || len(groups[0].Links) != 2 {
+		t.Errorf("groupPageLinks() first group = %q with %d links, want %q with 2", groups[0].Title, len(groups[0].Links), "Search Engines")
+	}
+	if groups[1].Title != "Shopping Sites" || len(groups[1].Links) != 1 {
+		t.Errorf("groupPageLinks() second group = %q with %d links, want %q with 1", groups[1].Title, len(groups[1].Links), "Shopping Sites")
+	}
+	if groups[0].Links[1].Engine.Name != "Google" {
+		t.Errorf("groupPageLinks() did not keep link order within a category")
+	}
+}
+
+func TestNewLandingPageData_EngineOrder(t *testing.T) {
+	links := []PageLink{
+		{Category: "search", Engine: SearchEngine{Name: "YouTube"}},
+		{Category: "technews", Engine: SearchEngine{Name: "Hacker News"}},
+		{Category: "search", Engine: SearchEngine{Name: "Google"}},
+	}
+
+	data := newLandingPageData("q", links)
+	var rendered []string
+	for _, group := range data.Groups {
+		for _, link := range group.Links {
+			rendered = append(rendered, link.Engine.Name)
+		}
+	}
+	if len(data.Engines) != len(rendered) {
+		t.Fatalf("newLandingPageData() has %d engines for %d links", len(data.Engines), len(rendered))
+	}
+	for i, name := range rendered {
+		if data.Engines[i].Name != name {
+			t.Errorf("newLandingPageData() engine %d = %q, want %q to match the link order", i, data.Engines[i].Name, name)
+		}
+	}
+}
+
+func TestWriteLandingPage(t *testing.T) {
+	bing := SearchEngine{Name: "Bing", URL: "https://www.bing.com/search?q=", Encoding: Encoding{Delimiter: "+"}}
+	swappa := SearchEngine{Name: "Swappa", URL: "https://swappa.com/search?q=", Encoding: Encoding{Delimiter: "%20"}}
+	query := `cats & "dogs" <script>`
+	links := []PageLink{
+		{Category: "search", Engine: bing, URL: BuildSearchURL(bing, query)},
+		{Category: "shop", Engine: swappa, URL: BuildSearchURL(swappa, query)},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteLandingPage(&buf, query, links); err != nil {
+		t.Fatalf("WriteLandingPage() error = %v", err)
+	}
+	page := buf.String()
+
+	wantContains := []string{
+		"<h2>Search Engines</h2>",
+		"<h2>Shopping Sites</h2>",
+		`href="https://www.bing.com/search?q=cats&#43;%26&#43;%22dogs%22&#43;%3Cscript%3E"`,
+		">Swappa</a>",
+		`"prefix":"https://swappa.com/search?q="`,
+		`"encoding":{"delimiter":"%20"}`,
+		`id="open-all"`,
+		`id="open-background"`,
+		`id="open-next"`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(page, want) {
+			t.Errorf("WriteLandingPage() output missing %q", want)
+		}
+	}
+
+	// The query must be escaped wherever it appears
+	if strings.Contains(page, "<script>\"") || strings.Contains(page, `"dogs" <script>`) {
+		t.Error("WriteLandingPage() did not escape the query")
+	}
+	// Self-contained: no external resources
+	if strings.Contains(page, "src=\"http") || strings.Contains(page, "<link ") {
+		t.Error("WriteLandingPage() references external resources")
+	}
+}
+
+func TestWriteLandingPageFile(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	engine := SearchEngine{Name: "Bing", URL: "https://www.bing.com/search?q=", Encoding: Encoding{Delimiter: "+"}}
+	links := []PageLink{{Category: "search", Engine: engine, URL: BuildSearchURL(engine, "test")}}
+
+	path, err := WriteLandingPageFile("test", links)
+	if err != nil {
+		t.Fatalf("WriteLandingPageFile() error = %v", err)
+	}
+	if !strings.HasSuffix(path, ".html") {
+		t.Errorf("WriteLandingPageFile() path = %q, want .html suffix", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read landing page: %v", err)
+	}
+	if !strings.Contains(string(data), "https://www.bing.com/search?q=test") {
+		t.Errorf("Landing page missing link\nGot: %s", data)
+	}
+}
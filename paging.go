@@ -0,0 +1,65 @@
+package hunt
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultPageSize is the results per page assumed for {offset} when an engine doesn't say
+const defaultPageSize = 10
+
+// PagingFilter maps --results-page and --num onto an engine's URL parameters
+type PagingFilter struct {
+	Page     map[string]string `json:"page,omitempty"`      // Parameters for --results-page; values may use {page}, {page0}, {offset} and {offset1}
+	Num      map[string]string `json:"num,omitempty"`       // Parameters for --num; values may use {num}
+	PageSize int               `json:"page_size,omitempty"` // Results per page for {offset} when --num isn't applied (default 10)
+}
+
+// Validate checks the page size
+func (p PagingFilter) Validate() error {
+	if p.PageSize < 0 {
+		return fmt.Errorf("paging: invalid page_size %d", p.PageSize)
+	}
+	return nil
+}
+
+// pageParams returns the parameters for the 1-based page, with num results per page if
+// the engine applies num (0 when it doesn't). Reports false if the engine can't page.
+func (p PagingFilter) pageParams(page, num int) (url.Values, bool) {
+	if len(p.Page) == 0 {
+		return nil, false
+	}
+	size := num
+	if size == 0 {
+		size = p.PageSize
+	}
+	if size == 0 {
+		size = defaultPageSize
+	}
+	offset := (page - 1) * size
+	replacer := strings.NewReplacer(
+		"{page}", strconv.Itoa(page),
+		"{page0}", strconv.Itoa(page-1),
+		"{offset}", strconv.Itoa(offset),
+		"{offset1}", strconv.Itoa(offset+1),
+	)
+	params := url.Values{}
+	for name, value := range p.Page {
+		params.Set(name, replacer.Replace(value))
+	}
+	return params, true
+}
+
+// numParams returns the parameters asking for num results per page, reporting false if the engine has none
+func (p PagingFilter) numParams(num int) (url.Values, bool) {
+	if len(p.Num) == 0 {
+		return nil, false
+	}
+	params := url.Values{}
+	for name, value := range p.Num {
+		params.Set(name, strings.ReplaceAll(value, "{num}", strconv.Itoa(num)))
+	}
+	return params, true
+}
@@ -0,0 +1,210 @@
+package hunt
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Query node kinds for QueryNode.Kind
+const (
+	NodeWord     = "word"
+	NodePhrase   = "phrase"   // A "quoted phrase"
+	NodeOperator = "operator" // name:value, e.g. site:go.dev
+	NodeBoolean  = "boolean"  // OR, AND or |
+)
+
+// QueryNode is one term of a parsed search query
+type QueryNode struct {
+	Kind     string
+	Text     string // The word, the phrase without quotes, the operator's value or the boolean keyword
+	Operator string // Lowercase operator name for NodeOperator, e.g. "site"
+	Exclude  bool   // Prefixed with "-"
+	Raw      string // The term as typed
+}
+
+// Query is a search term parsed into words, phrases, operators and booleans
+type Query []QueryNode
+
+// ParseQuery parses a search term; quoted phrases may contain spaces
+// Only the operators strip_operators knows (site:, filetype:, intitle: and so on) are
+// parsed as operators, so "10:30" or a URL stays a word.
+func ParseQuery(query string) Query {
+	var nodes Query
+	for _, raw := range splitQueryTerms(query) {
+		if node, ok := parseQueryNode(raw); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// splitQueryTerms splits query on spaces outside double quotes
+func splitQueryTerms(query string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+// parseQueryNode classifies one term; it reports false for terms with no text, such as a lone quote
+func parseQueryNode(raw string) (QueryNode, bool) {
+	if raw == "OR" || raw == "AND" || raw == "|" {
+		return QueryNode{Kind: NodeBoolean, Text: raw, Raw: raw}, true
+	}
+
+	node := QueryNode{Kind: NodeWord, Raw: raw}
+	body := raw
+	if len(body) > 1 && body[0] == '-' {
+		node.Exclude = true
+		body = body[1:]
+	}
+	if name, value, ok := strings.Cut(body, ":"); ok && value != "" && searchOperators[strings.ToLower(name)] {
+		node.Kind, node.Operator, node.Text = NodeOperator, strings.ToLower(name), strings.Trim(value, `"`)
+	} else if strings.HasPrefix(body, `"`) {
+		node.Kind, node.Text = NodePhrase, strings.Trim(body, `"`)
+	} else {
+		node.Text = strings.Trim(body, `"+`)
+	}
+	return node, node.Text != ""
+}
+
+// String returns the query as typed, with spacing normalized
+func (q Query) String() string {
+	raws := make([]string, len(q))
+	for i, node := range q {
+		raws[i] = node.Raw
+	}
+	return strings.Join(raws, " ")
+}
+
+// Operator syntaxes for OperatorDialect.Syntax
+const (
+	SyntaxNative = "native" // Operators are passed through as typed (the default)
+	SyntaxStrip  = "strip"  // Operators, -exclusions and booleans are dropped; phrases become plain words
+)
+
+// OperatorDialect describes which query operators an engine understands
+type OperatorDialect struct {
+	Syntax string            `json:"syntax,omitempty"`
+	Params map[string]string `json:"params,omitempty"` // Operator name to URL parameter, e.g. "site": "sitesearch"
+}
+
+// Validate checks the syntax and that every mapped operator is a known one
+func (d OperatorDialect) Validate() error {
+	switch d.Syntax {
+	case "", SyntaxNative, SyntaxStrip:
+	default:
+		return fmt.Errorf("invalid operator syntax %q (must be %q or %q)", d.Syntax, SyntaxNative, SyntaxStrip)
+	}
+	for operator, param := range d.Params {
+		if !searchOperators[operator] {
+			return fmt.Errorf("unknown operator %q in operator params", operator)
+		}
+		if param == "" {
+			return fmt.Errorf("operator %q has no URL parameter", operator)
+		}
+	}
+	return nil
+}
+
+// Render renders query in the dialect
+// Operators with a URL parameter are moved into params. dropped lists the parts of the
+// query the engine won't see, as typed.
+func (d OperatorDialect) Render(query string) (rendered string, params url.Values, dropped []string) {
+	if d.Syntax != SyntaxStrip && len(d.Params) == 0 {
+		return query, nil, nil
+	}
+
+	var kept []string
+	for _, node := range ParseQuery(query) {
+		if param := d.Params[node.Operator]; node.Kind == NodeOperator && !node.Exclude && param != "" {
+			if params == nil {
+				params = url.Values{}
+			}
+			params.Add(param, node.Text)
+			continue
+		}
+		if d.Syntax != SyntaxStrip {
+			kept = append(kept, node.Raw)
+			continue
+		}
+
+		switch {
+		case node.Exclude || node.Kind == NodeOperator || node.Kind == NodeBoolean:
+			dropped = append(dropped, node.Raw)
+		case node.Kind == NodePhrase:
+			if strings.Contains(node.Text, " ") {
+				dropped = append(dropped, fmt.Sprintf("quotes around %q", node.Text))
+			}
+			kept = append(kept, node.Text)
+		default:
+			kept = append(kept, node.Text)
+		}
+	}
+	return strings.Join(kept, " "), params, dropped
+}
+
+// PreparedQuery is what an engine receives for a search term
+type PreparedQuery struct {
+	Query   string     // After the operator dialect and query_transform steps
+	Params  url.Values // Extra URL parameters, such as operators mapped by the dialect
+	Dropped []string   // Parts of the search term the engine doesn't support
+}
+
+// PrepareQuery renders searchTerm in the engine's operator dialect, then applies its
+// query_transform steps
+func (e SearchEngine) PrepareQuery(searchTerm string) PreparedQuery {
+	query, params, dropped := e.Operators.Render(searchTerm)
+	for _, step := range e.QueryTransform {
+		query = step.Apply(query)
+	}
+	return PreparedQuery{Query: query, Params: params, Dropped: dropped}
+}
+
+// addURLParams appends params to a search URL, whose query is usually its last parameter
+func addURLParams(searchURL string, params url.Values) string {
+	if len(params) == 0 {
+		return searchURL
+	}
+	separator := "?"
+	if strings.Contains(searchURL, "?") {
+		separator = "&"
+	}
+	return searchURL + separator + params.Encode()
+}
+
+// paramFields returns params as form fields, sorted by name like url.Values.Encode
+func paramFields(params url.Values) []FormField {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []FormField
+	for _, name := range names {
+		for _, value := range params[name] {
+			fields = append(fields, FormField{Name: name, Value: value})
+		}
+	}
+	return fields
+}
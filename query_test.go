@@ -0,0 +1,123 @@
+package hunt
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	got := ParseQuery(`"error handling"  site:Go.dev -java OR +generics -"old api" intitle:"release notes" 10:30 "`)
+	want := Query{
+		{Kind: NodePhrase, Text: "error handling", Raw: `"error handling"`},
+		{Kind: NodeOperator, Operator: "site", Text: "Go.dev", Raw: "site:Go.dev"},
+		{Kind: NodeWord, Text: "java", Exclude: true, Raw: "-java"},
+		{Kind: NodeBoolean, Text: "OR", Raw: "OR"},
+		{Kind: NodeWord, Text: "generics", Raw: "+generics"},
+		{Kind: NodePhrase, Text: "old api", Exclude: true, Raw: `-"old api"`},
+		{Kind: NodeOperator, Operator: "intitle", Text: "release notes", Raw: `intitle:"release notes"`},
+		{Kind: NodeWord, Text: "10:30", Raw: "10:30"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseQuery() =\n%+v\nwant\n%+v", got, want)
+	}
+	if s := got.String(); s != `"error handling" site:Go.dev -java OR +generics -"old api" intitle:"release notes" 10:30` {
+		t.Errorf("Query.String() = %q", s)
+	}
+}
+
+func TestOperatorDialect_Render(t *testing.T) {
+	query := `"error handling" site:go.dev -java OR generics`
+
+	tests := []struct {
+		name        string
+		dialect     OperatorDialect
+		want        string
+		wantParams  url.Values
+		wantDropped []string
+	}{
+		{
+			name:    "native is unchanged",
+			dialect: OperatorDialect{},
+			want:    query,
+		},
+		{
+			name:        "strip",
+			dialect:     OperatorDialect{Syntax: SyntaxStrip},
+			want:        "error handling generics",
+			wantDropped: []string{`quotes around "error handling"`, "site:go.dev", "-java", "OR"},
+		},
+		{
+			name:       "native with params",
+			dialect:    OperatorDialect{Params: map[string]string{"site": "sitesearch"}},
+			want:       `"error handling" -java OR generics`,
+			wantParams: url.Values{"sitesearch": {"go.dev"}},
+		},
+		{
+			name:        "strip with params",
+			dialect:     OperatorDialect{Syntax: SyntaxStrip, Params: map[string]string{"site": "sitesearch"}},
+			want:        "error handling generics",
+			wantParams:  url.Values{"sitesearch": {"go.dev"}},
+			wantDropped: []string{`quotes around "error handling"`, "-java", "OR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, params, dropped := tt.dialect.Render(query)
+			if got != tt.want {
+				t.Errorf("Render() query = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("Render() params = %v, want %v", params, tt.wantParams)
+			}
+			if !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("Render() dropped = %q, want %q", dropped, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestBuildSearchRequest_OperatorParams(t *testing.T) {
+	operators := OperatorDialect{Params: map[string]string{"site": "as_sitesearch", "filetype": "as_filetype"}}
+
+	get := SearchEngine{Name: "Google", URL: "https://www.google.com/search?q=", Operators: operators}
+	if got, want := BuildSearchURL(get, "gopher site:go.dev filetype:pdf"), "https://www.google.com/search?q=gopher&as_filetype=pdf&as_sitesearch=go.dev"; got != want {
+		t.Errorf("BuildSearchURL() = %q, want %q", got, want)
+	}
+
+	path := SearchEngine{Name: "Wiki", URL: "https://wiki.example.com/search/", Operators: operators, Encoding: Encoding{Escape: EscapePath, Delimiter: "%20"}}
+	if got, want := BuildSearchURL(path, "gopher site:go.dev"), "https://wiki.example.com/search/gopher?as_sitesearch=go.dev"; got != want {
+		t.Errorf("BuildSearchURL() = %q, want %q", got, want)
+	}
+
+	post := SearchEngine{Name: "Catalog", URL: "https://catalog.example.edu/search", Method: MethodPost, Operators: operators,
+		Form: []FormField{{Name: "terms", Value: FormQueryPlaceholder}}}
+	req := BuildSearchRequest(post, "gopher site:go.dev")
+	if want := []FormField{{Name: "terms", Value: "gopher"}, {Name: "as_sitesearch", Value: "go.dev"}}; !reflect.DeepEqual(req.Fields, want) {
+		t.Errorf("BuildSearchRequest() fields = %+v, want %+v", req.Fields, want)
+	}
+}
+
+func TestLoadConfig_Operators(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{"strip", `{"shop": [{"name": "Amazon", "url": "https://www.amazon.com/s?k=", "operators": {"syntax": "strip"}}]}`, false},
+		{"params", `{"search": [{"name": "Google", "url": "https://www.google.com/search?q=", "operators": {"params": {"site": "as_sitesearch"}}}]}`, false},
+		{"unknown syntax", `{"shop": [{"name": "Amazon", "url": "https://www.amazon.com/s?k=", "operators": {"syntax": "lucene"}}]}`, true},
+		{"unknown operator", `{"search": [{"name": "Google", "url": "https://www.google.com/search?q=", "operators": {"params": {"near": "loc"}}}]}`, true},
+		{"empty param", `{"search": [{"name": "Google", "url": "https://www.google.com/search?q=", "operators": {"params": {"site": ""}}}]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
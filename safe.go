@@ -0,0 +1,52 @@
+package hunt
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SafeSearch levels for --safe
+const (
+	SafeStrict   = "strict"
+	SafeModerate = "moderate"
+	SafeOff      = "off"
+)
+
+// SafeSearchLevels lists the --safe levels from strictest to least strict
+var SafeSearchLevels = []string{SafeStrict, SafeModerate, SafeOff}
+
+// ParseSafeSearch validates a --safe level (case-insensitive) and returns its canonical form
+func ParseSafeSearch(level string) (string, error) {
+	for _, known := range SafeSearchLevels {
+		if strings.EqualFold(level, known) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unknown safe search level %q (want %s)", level, strings.Join(SafeSearchLevels, ", "))
+}
+
+// SafeSearchFilter maps each --safe level to an engine's parameters, e.g. "strict": {"safe": "active"}
+type SafeSearchFilter map[string]map[string]string
+
+// Validate checks the levels and that each has parameters
+func (f SafeSearchFilter) Validate() error {
+	for level, params := range f {
+		if canonical, err := ParseSafeSearch(level); err != nil || canonical != level {
+			return fmt.Errorf("safe_search: unknown level %q (want %s)", level, strings.Join(SafeSearchLevels, ", "))
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("safe_search: level %q has no parameters", level)
+		}
+	}
+	return nil
+}
+
+// params returns the parameters for level, reporting false if the engine has none
+func (f SafeSearchFilter) params(level string) (url.Values, bool) {
+	params, ok := f[level]
+	if !ok {
+		return nil, false
+	}
+	return toValues(params), true
+}
@@ -1,34 +1,40 @@
-package main
+package hunt
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 )
 
+// Special results of ResolveSelection
+const (
+	SelectionInvalid = -1 // The selection matches no engine
+	SelectionAll     = -2 // The selection is "all" or 0
+)
+
 // ResolveSelection resolves a service selection (number or name) to an engine index
-// Returns the index, or -1 if not found, or -2 if "all" is selected
+// Returns the index, or SelectionInvalid if not found, or SelectionAll if "all" is selected
 func ResolveSelection(selection string, engines []SearchEngine) int {
 	selection = strings.TrimSpace(selection)
 	selectionLower := strings.ToLower(selection)
 
 	// Check for "all" option
 	if selectionLower == "all" || selection == "0" {
-		return -2 // Special marker for "all"
+		return SelectionAll
 	}
 
 	// Check if it's a number
 	if num, err := strconv.Atoi(selection); err == nil {
 		if num == 0 {
-			return -2 // "all" option
+			return SelectionAll
 		}
 		// Convert 1-indexed to 0-indexed
 		idx := num - 1
 		if idx >= 0 && idx < len(engines) {
 			return idx
 		}
-		return -1 // Invalid number
+		return SelectionInvalid
 	}
 
 	// Try to match by name (case-insensitive)
@@ -38,19 +44,23 @@ func ResolveSelection(selection string, engines []SearchEngine) int {
 		}
 	}
 
-	return -1 // Not found
+	return SelectionInvalid
 }
 
 // ParseSelections parses service selections and returns a list of engine indices
 // Removes duplicates and handles "all" selection
-func ParseSelections(selections []string, engines []SearchEngine) ([]int, error) {
+// Invalid selections are skipped with a warning written to w, which may be nil
+func ParseSelections(w io.Writer, selections []string, engines []SearchEngine) ([]int, error) {
+	if w == nil {
+		w = io.Discard
+	}
 	var indices []int
 	seen := make(map[int]bool)
 
 	for _, selection := range selections {
 		resolved := ResolveSelection(selection, engines)
 
-		if resolved == -2 {
+		if resolved == SelectionAll {
 			// "all" selected - return all indices
 			allIndices := make([]int, len(engines))
 			for i := range engines {
@@ -59,8 +69,8 @@ func ParseSelections(selections []string, engines []SearchEngine) ([]int, error)
 			return allIndices, nil
 		}
 
-		if resolved == -1 {
-			fmt.Fprintf(os.Stderr, "Warning: Invalid selection '%s', skipping...\n", selection)
+		if resolved == SelectionInvalid {
+			fmt.Fprintf(w, "Warning: Invalid selection '%s', skipping...\n", selection)
 			continue
 		}
 
@@ -78,3 +88,64 @@ func ParseSelections(selections []string, engines []SearchEngine) ([]int, error)
 	return indices, nil
 }
 
+// SelectedEngine is an engine together with the category it was selected from
+type SelectedEngine struct {
+	Category string
+	Engine   SearchEngine
+}
+
+// SelectEngines resolves selections against each of the given categories
+// A selection may match in any category; categories with no matching selection contribute nothing.
+// With no selections, every engine in every category is selected. Returns the selected engines
+// in category order, plus any selections that matched no engine at all.
+func SelectEngines(config *Config, categories []string, selections []string) ([]SelectedEngine, []string, error) {
+	for _, category := range categories {
+		if len(config.GetEnginesByCategory(category)) == 0 {
+			return nil, nil, fmt.Errorf("no services found for category %q", category)
+		}
+	}
+
+	var selected []SelectedEngine
+	matched := make(map[string]bool)
+	for _, category := range categories {
+		engines := config.GetEnginesByCategory(category)
+
+		seen := make(map[int]bool)
+		var indices []int
+		for _, selection := range selections {
+			resolved := ResolveSelection(selection, engines)
+			if resolved == SelectionInvalid {
+				continue
+			}
+			matched[selection] = true
+			if resolved == SelectionAll {
+				indices = nil
+				for i := range engines {
+					indices = append(indices, i)
+				}
+				break
+			}
+			if !seen[resolved] {
+				indices = append(indices, resolved)
+				seen[resolved] = true
+			}
+		}
+		if len(selections) == 0 {
+			for i := range engines {
+				indices = append(indices, i)
+			}
+		}
+
+		for _, idx := range indices {
+			selected = append(selected, SelectedEngine{Category: category, Engine: engines[idx]})
+		}
+	}
+
+	var unmatched []string
+	for _, selection := range selections {
+		if !matched[selection] {
+			unmatched = append(unmatched, selection)
+		}
+	}
+	return selected, unmatched, nil
+}
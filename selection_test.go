@@ -1,6 +1,9 @@
-package main
+package hunt
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestResolveSelection(t *testing.T) {
 	engines := []SearchEngine{
@@ -203,7 +206,7 @@ func TestParseSelections(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ParseSelections(tt.selections, engines)
+			got, err := ParseSelections(nil, tt.selections, engines)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ParseSelections(%v, engines) error = %v, wantErr %v", tt.selections, err, tt.wantErr)
 				return
@@ -229,3 +232,60 @@ func equalIntSlices(a, b []int) bool {
 }
 
 
+
+func TestSelectEngines(t *testing.T) {
+	config := &Config{Categories: map[string][]SearchEngine{
+		"search": {{Name: "Bing"}, {Name: "Google"}},
+		"shop":   {{Name: "Amazon"}, {Name: "Swappa"}},
+	}}
+
+	tests := []struct {
+		name          string
+		categories    []string
+		selections    []string
+		wantNames     []string
+		wantUnmatched []string
+		wantErr       bool
+	}{
+		{"no selections selects everything", []string{"search", "shop"}, nil, []string{"Bing", "Google", "Amazon", "Swappa"}, nil, false},
+		{"names across categories", []string{"search", "shop"}, []string{"google", "Swappa"}, []string{"Google", "Swappa"}, nil, false},
+		{"all within a category", []string{"shop"}, []string{"all"}, []string{"Amazon", "Swappa"}, nil, false},
+		{"unmatched reported", []string{"search"}, []string{"bing", "altavista"}, []string{"Bing"}, []string{"altavista"}, false},
+		{"unknown category", []string{"nope"}, nil, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, unmatched, err := SelectEngines(config, tt.categories, tt.selections)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectEngines() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, s := range selected {
+				names = append(names, s.Engine.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("SelectEngines() selected %v, want %v", names, tt.wantNames)
+			}
+			if strings.Join(unmatched, ",") != strings.Join(tt.wantUnmatched, ",") {
+				t.Errorf("SelectEngines() unmatched %v, want %v", unmatched, tt.wantUnmatched)
+			}
+		})
+	}
+}
+
+func TestParseSelections_WarningsGoToWriter(t *testing.T) {
+	engines := []SearchEngine{{Name: "Bing"}, {Name: "Google"}}
+
+	var warnings strings.Builder
+	indices, err := ParseSelections(&warnings, []string{"bing", "altavista"}, engines)
+	if err != nil {
+		t.Fatalf("ParseSelections() error = %v", err)
+	}
+	if len(indices) != 1 || indices[0] != 0 {
+		t.Errorf("ParseSelections() = %v, want [0]", indices)
+	}
+	if !strings.Contains(warnings.String(), "Invalid selection 'altavista'") {
+		t.Errorf("ParseSelections() warnings = %q, want a warning for altavista", warnings.String())
+	}
+}
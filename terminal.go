@@ -0,0 +1,64 @@
+package hunt
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// IsRemoteSession reports whether URLs cannot be opened in a local browser, either because
+// there is no graphical display or because hunt is running over SSH without one
+// getenv is normally os.Getenv; goos is normally runtime.GOOS
+func IsRemoteSession(getenv func(string) string, goos string) bool {
+	hasDisplay := getenv("DISPLAY") != "" || getenv("WAYLAND_DISPLAY") != ""
+	overSSH := getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != ""
+
+	switch goos {
+	case "darwin", "windows":
+		// The desktop is always available locally; over SSH it belongs to the wrong machine
+		return overSSH && !hasDisplay
+	default:
+		// X11/Wayland systems need a display, whether or not the session is remote
+		return !hasDisplay
+	}
+}
+
+// osc8Link wraps text in an OSC 8 escape sequence so terminals render it as a clickable link to url
+func osc8Link(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// WriteLinks prints each target as a "Name: URL" line
+// When hyperlinks is true the URL is wrapped in an OSC 8 sequence so it can be clicked
+func WriteLinks(w io.Writer, targets []OpenTarget, hyperlinks bool) {
+	width := 0
+	for i, target := range targets {
+		width = max(width, len(targetName(i, target)))
+	}
+
+	for i, target := range targets {
+		link := target.URL
+		if hyperlinks {
+			link = osc8Link(target.URL, target.URL)
+		}
+		suffix := ""
+		if target.Private {
+			suffix = " (private)"
+		}
+		fmt.Fprintf(w, "  %-*s  %s%s\n", width+1, targetName(i, target)+":", link, suffix)
+	}
+}
+
+// WriteClipboard asks the terminal to copy text to the local clipboard using OSC 52
+// This works over SSH because the terminal emulator, not the remote host, owns the clipboard.
+// Inside tmux the sequence is wrapped in a passthrough so it reaches the outer terminal.
+func WriteClipboard(w io.Writer, text string, inTmux bool) error {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if inTmux {
+		// tmux passthrough: ESC characters inside the payload must be doubled
+		sequence = "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	_, err := io.WriteString(w, sequence)
+	return err
+}
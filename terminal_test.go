@@ -0,0 +1,135 @@
+package hunt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestIsRemoteSession(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		goos string
+		want bool
+	}{
+		{
+			name: "linux with X11 display",
+			env:  map[string]string{"DISPLAY": ":0"},
+			goos: "linux",
+			want: false,
+		},
+		{
+			name: "linux with Wayland display",
+			env:  map[string]string{"WAYLAND_DISPLAY": "wayland-0"},
+			goos: "linux",
+			want: false,
+		},
+		{
+			name: "linux without display",
+			env:  map[string]string{},
+			goos: "linux",
+			want: true,
+		},
+		{
+			name: "linux over SSH without display",
+			env:  map[string]string{"SSH_CONNECTION": "10.0.0.2 50000 10.0.0.1 22"},
+			goos: "linux",
+			want: true,
+		},
+		{
+			name: "linux over SSH with X forwarding",
+			env:  map[string]string{"SSH_CONNECTION": "10.0.0.2 50000 10.0.0.1 22", "DISPLAY": "localhost:10.0"},
+			goos: "linux",
+			want: false,
+		},
+		{
+			name: "macOS desktop",
+			env:  map[string]string{},
+			goos: "darwin",
+			want: false,
+		},
+		{
+			name: "macOS over SSH",
+			env:  map[string]string{"SSH_TTY": "/dev/ttys001"},
+			goos: "darwin",
+			want: true,
+		},
+		{
+			name: "windows desktop",
+			env:  map[string]string{},
+			goos: "windows",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := IsRemoteSession(getenv, tt.goos); got != tt.want {
+				t.Errorf("IsRemoteSession(%v, %q) = %v, want %v", tt.env, tt.goos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteLinks(t *testing.T) {
+	targets := []OpenTarget{
+		{Name: "Bing", URL: "https://www.bing.com/search?q=test"},
+		{Name: "DuckDuckGo", URL: "https://duckduckgo.com/?q=test", Private: true},
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		WriteLinks(&buf, targets, false)
+		want := "  Bing:        https://www.bing.com/search?q=test\n" +
+			"  DuckDuckGo:  https://duckduckgo.com/?q=test (private)\n"
+		if buf.String() != want {
+			t.Errorf("WriteLinks() = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("hyperlinks", func(t *testing.T) {
+		var buf bytes.Buffer
+		WriteLinks(&buf, targets, true)
+		wantLink := "\x1b]8;;https://www.bing.com/search?q=test\x1b\\https://www.bing.com/search?q=test\x1b]8;;\x1b\\"
+		if !strings.Contains(buf.String(), wantLink) {
+			t.Errorf("WriteLinks() = %q, want OSC 8 link %q", buf.String(), wantLink)
+		}
+	})
+}
+
+func TestWriteClipboard(t *testing.T) {
+	text := "https://a.example/?q=1\nhttps://b.example/?q=2"
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+
+	tests := []struct {
+		name   string
+		inTmux bool
+		want   string
+	}{
+		{
+			name:   "plain terminal",
+			inTmux: false,
+			want:   "\x1b]52;c;" + encoded + "\a",
+		},
+		{
+			name:   "inside tmux",
+			inTmux: true,
+			want:   "\x1bPtmux;\x1b\x1b]52;c;" + encoded + "\a\x1b\\",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteClipboard(&buf, text, tt.inTmux); err != nil {
+				t.Fatalf("WriteClipboard() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("WriteClipboard() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
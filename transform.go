@@ -0,0 +1,91 @@
+package hunt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Query transform steps for QueryTransform.Op
+const (
+	TransformPrefix         = "prefix"          // Prepend Value
+	TransformSuffix         = "suffix"          // Append Value
+	TransformReplace        = "replace"         // Replace matches of the regular expression Pattern with Value
+	TransformQuote          = "quote"           // Search the whole query as one quoted phrase
+	TransformStripOperators = "strip_operators" // Drop operators such as site:, -word, OR and quotes
+)
+
+// QueryTransform is one step in rewriting the query before an engine receives it
+type QueryTransform struct {
+	Op      string `json:"op"`
+	Value   string `json:"value,omitempty"`
+	Pattern string `json:"pattern,omitempty"` // Go regexp syntax; $1 in Value refers to a group
+
+	re *regexp.Regexp // Compiled Pattern
+}
+
+// searchOperators are the name: operators strip_operators removes
+var searchOperators = map[string]bool{
+	"site": true, "filetype": true, "ext": true, "inurl": true, "allinurl": true,
+	"intitle": true, "allintitle": true, "intext": true, "allintext": true,
+	"inanchor": true, "related": true, "cache": true, "info": true, "define": true,
+	"before": true, "after": true, "source": true, "location": true, "lang": true,
+}
+
+// Validate checks the step's operation and compiles its pattern
+func (t *QueryTransform) Validate() error {
+	switch t.Op {
+	case TransformPrefix, TransformSuffix:
+		if t.Value == "" {
+			return fmt.Errorf("%s step needs a value", t.Op)
+		}
+	case TransformReplace:
+		re, err := regexp.Compile(t.Pattern)
+		if err != nil {
+			return fmt.Errorf("replace step: invalid pattern: %w", err)
+		}
+		t.re = re
+	case TransformQuote, TransformStripOperators:
+	default:
+		return fmt.Errorf("unknown query_transform op %q", t.Op)
+	}
+	return nil
+}
+
+// Apply returns query with the step applied
+func (t QueryTransform) Apply(query string) string {
+	switch t.Op {
+	case TransformPrefix:
+		return t.Value + query
+	case TransformSuffix:
+		return query + t.Value
+	case TransformReplace:
+		re := t.re
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(t.Pattern); err != nil {
+				return query
+			}
+		}
+		return re.ReplaceAllString(query, t.Value)
+	case TransformQuote:
+		return `"` + strings.ReplaceAll(strings.TrimSpace(query), `"`, "") + `"`
+	case TransformStripOperators:
+		return StripOperators(query)
+	}
+	return query
+}
+
+// StripOperators removes search operators from query: name:value operators such as
+// site:example.com, excluded -words and -"phrases", OR/AND and |, and quotes and + around words
+// It renders the query in the strip operator dialect, so ParseQuery decides what is an operator.
+func StripOperators(query string) string {
+	rendered, _, _ := OperatorDialect{Syntax: SyntaxStrip}.Render(query)
+	return rendered
+}
+
+// TransformQuery returns the query the engine receives after its operator dialect and
+// query_transform steps
+func (e SearchEngine) TransformQuery(query string) string {
+	return e.PrepareQuery(query).Query
+}
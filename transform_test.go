@@ -0,0 +1,92 @@
+package hunt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchEngine_TransformQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []QueryTransform
+		query string
+		want  string
+	}{
+		{name: "no steps", query: "rust async", want: "rust async"},
+		{name: "prefix", steps: []QueryTransform{{Op: TransformPrefix, Value: "site:reddit.com "}}, query: "rust async", want: "site:reddit.com rust async"},
+		{name: "suffix", steps: []QueryTransform{{Op: TransformSuffix, Value: " used"}}, query: "thinkpad x1", want: "thinkpad x1 used"},
+		{name: "replace", steps: []QueryTransform{{Op: TransformReplace, Pattern: `\bvs\.?\s`, Value: "versus "}}, query: "go vs rust", want: "go versus rust"},
+		{name: "replace with group", steps: []QueryTransform{{Op: TransformReplace, Pattern: `(\d+)gb`, Value: "$1 GB"}}, query: "ssd 512gb", want: "ssd 512 GB"},
+		{name: "quote", steps: []QueryTransform{{Op: TransformQuote}}, query: ` the "quick" fox `, want: `"the quick fox"`},
+		{
+			name:  "strip operators",
+			steps: []QueryTransform{{Op: TransformStripOperators}},
+			query: `"error handling" site:go.dev -java OR +generics filetype:pdf https://example.com`,
+			want:  "error handling generics https://example.com",
+		},
+		{
+			name:  "strip operators with quoted values",
+			steps: []QueryTransform{{Op: TransformStripOperators}},
+			query: `rust -"foo bar" site:"a b" "async io"`,
+			want:  "rust async io",
+		},
+		{
+			name:  "steps apply in order",
+			steps: []QueryTransform{{Op: TransformStripOperators}, {Op: TransformQuote}, {Op: TransformPrefix, Value: "site:reddit.com "}},
+			query: "mechanical keyboard -cherry",
+			want:  `site:reddit.com "mechanical keyboard"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := SearchEngine{Name: "Test", URL: "https://example.com/?q=", QueryTransform: tt.steps}
+			if got := engine.TransformQuery(tt.query); got != tt.want {
+				t.Errorf("TransformQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSearchURL_QueryTransform(t *testing.T) {
+	engine := SearchEngine{
+		Name:           "Amazon used",
+		URL:            "https://www.amazon.com/s?k=",
+		QueryTransform: []QueryTransform{{Op: TransformSuffix, Value: " used"}},
+	}
+	if got, want := BuildSearchURL(engine, "kindle"), "https://www.amazon.com/s?k=kindle+used"; got != want {
+		t.Errorf("BuildSearchURL() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadConfig_QueryTransform(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   string
+		wantErr string
+	}{
+		{name: "valid", steps: `[{"op": "strip_operators"}, {"op": "replace", "pattern": "\\bvs\\b", "value": "versus"}]`},
+		{name: "unknown op", steps: `[{"op": "reverse"}]`, wantErr: `unknown query_transform op "reverse"`},
+		{name: "bad pattern", steps: `[{"op": "replace", "pattern": "(unclosed"}]`, wantErr: "invalid pattern"},
+		{name: "empty prefix", steps: `[{"op": "prefix"}]`, wantErr: "prefix step needs a value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"search": [{"name": "A", "url": "https://a.example/?q=", "query_transform": ` + tt.steps + `}]}`
+			config, err := LoadConfig(WithBytes([]byte(data)))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("LoadConfig() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if got := config.Categories["search"][0].TransformQuery("go vs rust -java"); got != "go versus rust" {
+				t.Errorf("TransformQuery() = %q, want %q", got, "go versus rust")
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package hunt
 
 import (
 	"net/url"
@@ -25,7 +25,8 @@ func URLEncode(searchTerm string, spaceDelimiter string) string {
 
 // BuildSearchURL constructs a complete search URL for a given engine and search term
 func BuildSearchURL(engine SearchEngine, searchTerm string) string {
-	encoded := URLEncode(searchTerm, engine.SpaceDelimiter)
-	return engine.URL + encoded
+	prepared := engine.PrepareQuery(searchTerm)
+	encoded := engine.Encoding.Encode(prepared.Query)
+	return addURLParams(engine.URL+encoded, prepared.Params)
 }
 
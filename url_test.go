@@ -1,4 +1,4 @@
-package main
+package hunt
 
 import "testing"
 
@@ -85,9 +85,9 @@ func TestBuildSearchURL(t *testing.T) {
 		{
 			name: "Google with plus delimiter",
 			engine: SearchEngine{
-				Name:           "Google",
-				URL:            "https://www.google.com/search?q=",
-				SpaceDelimiter: "+",
+				Name:     "Google",
+				URL:      "https://www.google.com/search?q=",
+				Encoding: Encoding{Delimiter: "+"},
 			},
 			searchTerm: "test query",
 			want:       "https://www.google.com/search?q=test+query",
@@ -95,9 +95,9 @@ func TestBuildSearchURL(t *testing.T) {
 		{
 			name: "Yahoo with plus delimiter",
 			engine: SearchEngine{
-				Name:           "Yahoo",
-				URL:            "https://search.yahoo.com/search?p=",
-				SpaceDelimiter: "+",
+				Name:     "Yahoo",
+				URL:      "https://search.yahoo.com/search?p=",
+				Encoding: Encoding{Delimiter: "+"},
 			},
 			searchTerm: "machine learning",
 			want:       "https://search.yahoo.com/search?p=machine+learning",
@@ -105,9 +105,9 @@ func TestBuildSearchURL(t *testing.T) {
 		{
 			name: "YouTube with special characters",
 			engine: SearchEngine{
-				Name:           "YouTube",
-				URL:            "https://www.youtube.com/results?search_query=",
-				SpaceDelimiter: "+",
+				Name:     "YouTube",
+				URL:      "https://www.youtube.com/results?search_query=",
+				Encoding: Encoding{Delimiter: "+"},
 			},
 			searchTerm: "C++ tutorial",
 			want:       "https://www.youtube.com/results?search_query=C%2B%2B+tutorial",
@@ -115,9 +115,9 @@ func TestBuildSearchURL(t *testing.T) {
 		{
 			name: "custom delimiter",
 			engine: SearchEngine{
-				Name:           "Test",
-				URL:            "https://test.com/search?q=",
-				SpaceDelimiter: "%20",
+				Name:     "Test",
+				URL:      "https://test.com/search?q=",
+				Encoding: Encoding{Delimiter: "%20"},
 			},
 			searchTerm: "hello world",
 			want:       "https://test.com/search?q=hello%20world",
@@ -133,5 +133,3 @@ func TestBuildSearchURL(t *testing.T) {
 		})
 	}
 }
-
-
@@ -0,0 +1,70 @@
+package hunt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Search verticals an engine can declare under "verticals"
+const (
+	VerticalImages  = "images"
+	VerticalVideos  = "videos"
+	VerticalNews    = "news"
+	VerticalMaps    = "maps"
+	VerticalScholar = "scholar"
+)
+
+// Verticals lists the supported verticals in the order they are documented
+var Verticals = []string{VerticalImages, VerticalVideos, VerticalNews, VerticalMaps, VerticalScholar}
+
+// ParseVertical validates a vertical name (case-insensitive) and returns its canonical form
+func ParseVertical(name string) (string, error) {
+	for _, vertical := range Verticals {
+		if strings.EqualFold(name, vertical) {
+			return vertical, nil
+		}
+	}
+	return "", fmt.Errorf("unknown vertical %q (want %s)", name, strings.Join(Verticals, ", "))
+}
+
+// validateVerticals checks that every declared vertical is known and has a URL
+func (e SearchEngine) validateVerticals() error {
+	for name, url := range e.Verticals {
+		if vertical, err := ParseVertical(name); err != nil || vertical != name {
+			return fmt.Errorf("unknown vertical %q (want %s)", name, strings.Join(Verticals, ", "))
+		}
+		if url == "" {
+			return fmt.Errorf("vertical %q has no URL", name)
+		}
+	}
+	return nil
+}
+
+// ForVertical returns the engine with its URL replaced by the vertical's URL
+// Everything else, such as encoding and query_transform, is kept. Reports false if the
+// engine has no such vertical.
+func (e SearchEngine) ForVertical(vertical string) (SearchEngine, bool) {
+	url, ok := e.Verticals[vertical]
+	if !ok {
+		return e, false
+	}
+	e.URL = url
+	return e, true
+}
+
+// SelectVertical points each selected engine at the vertical
+// Engines without it are dropped, or kept on their main search when fallback is set;
+// either way their names are returned in missing.
+func SelectVertical(selected []SelectedEngine, vertical string, fallback bool) (kept []SelectedEngine, missing []string) {
+	for _, s := range selected {
+		engine, ok := s.Engine.ForVertical(vertical)
+		if !ok {
+			missing = append(missing, s.Engine.Name)
+			if !fallback {
+				continue
+			}
+		}
+		kept = append(kept, SelectedEngine{Category: s.Category, Engine: engine})
+	}
+	return kept, missing
+}
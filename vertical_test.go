@@ -0,0 +1,92 @@
+package hunt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVertical(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"images", VerticalImages, false},
+		{"Scholar", VerticalScholar, false},
+		{"MAPS", VerticalMaps, false},
+		{"shopping", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseVertical(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVertical(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseVertical(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectVertical(t *testing.T) {
+	google := SearchEngine{Name: "Google", URL: "https://www.google.com/search?q=", Verticals: map[string]string{
+		VerticalImages: "https://www.google.com/search?tbm=isch&q=",
+	}}
+	mojeek := SearchEngine{Name: "Mojeek", URL: "https://www.mojeek.com/search?q="}
+	selected := []SelectedEngine{{Category: "search", Engine: google}, {Category: "search", Engine: mojeek}}
+
+	tests := []struct {
+		name        string
+		fallback    bool
+		wantURLs    []string
+		wantMissing []string
+	}{
+		{"skip", false, []string{"https://www.google.com/search?tbm=isch&q="}, []string{"Mojeek"}},
+		{"fallback", true, []string{"https://www.google.com/search?tbm=isch&q=", "https://www.mojeek.com/search?q="}, []string{"Mojeek"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, missing := SelectVertical(selected, VerticalImages, tt.fallback)
+			var urls []string
+			for _, s := range kept {
+				urls = append(urls, s.Engine.URL)
+			}
+			if !reflect.DeepEqual(urls, tt.wantURLs) {
+				t.Errorf("SelectVertical() URLs = %v, want %v", urls, tt.wantURLs)
+			}
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("SelectVertical() missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+
+	if selected[0].Engine.URL != google.URL {
+		t.Error("SelectVertical() modified the selected engines")
+	}
+}
+
+func TestLoadConfig_Verticals(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{"known verticals", `{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "verticals": {"images": "https://www.bing.com/images/search?q=", "news": "https://www.bing.com/news/search?q="}}]}`, false},
+		{"unknown vertical", `{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "verticals": {"shopping": "https://www.bing.com/shop?q="}}]}`, true},
+		{"vertical names are lowercase", `{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "verticals": {"Images": "https://www.bing.com/images/search?q="}}]}`, true},
+		{"empty URL", `{"search": [{"name": "Bing", "url": "https://www.bing.com/search?q=", "verticals": {"images": ""}}]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}